@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version is the library version reported over FFI
+const Version = "1.1.0"
+
+// Info describes the build of the library loaded by the host application
+type Info struct {
+	Version   string   `json:"version"`
+	GoVersion string   `json:"go_version"`
+	Commit    string   `json:"commit"`
+	Features  []string `json:"features"`
+}
+
+// Get returns the build info of the running binary.
+// Commit is the VCS revision stamped by the Go toolchain, suffixed with
+// "-dirty" for builds from a modified tree, or empty when it was not recorded
+// (e.g. test binaries).
+func Get() Info {
+	return Info{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+		Commit:    vcsCommit(),
+		Features:  Capabilities(),
+	}
+}
+
+// vcsCommit reads the VCS revision from the embedded build settings
+func vcsCommit() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+
+	if revision != "" && modified {
+		revision += "-dirty"
+	}
+	return revision
+}
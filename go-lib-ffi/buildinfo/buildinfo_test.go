@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+)
+
+func TestGetJSONKeys(t *testing.T) {
+	data, err := json.Marshal(Get())
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+
+	for _, key := range []string{"version", "go_version", "commit", "features"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("Get() failed\nExpected key: %s\nGot:          %s", key, data)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	info := Get()
+	if info.Version != Version {
+		t.Errorf("Get() failed\nExpected Version: %s\nGot:              %s", Version, info.Version)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("Get() failed\nExpected GoVersion: %s\nGot:                %s", runtime.Version(), info.GoVersion)
+	}
+	if len(info.Features) == 0 {
+		t.Errorf("Get() failed\nExpected: at least one feature\nGot:      none")
+	}
+}
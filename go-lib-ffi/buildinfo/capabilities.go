@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"slices"
+	"sync"
+)
+
+// Engine capabilities are registered as EnginePrefix + engine name
+const EnginePrefix = "engine:"
+
+var (
+	capabilitiesMu sync.Mutex
+	capabilities   = map[string]bool{}
+)
+
+// Register records a capability compiled into the library.
+// Packages call it from init so the list follows whatever is linked in.
+func Register(names ...string) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+
+	for _, name := range names {
+		capabilities[name] = true
+	}
+}
+
+// Capabilities returns the registered capability names in sorted order
+func Capabilities() []string {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+
+	names := make([]string, 0, len(capabilities))
+	for name := range capabilities {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,78 @@
+package buildinfo_test
+
+import (
+	"slices"
+	"testing"
+
+	"go-lib-ffi/buildinfo"
+	_ "go-lib-ffi/html"
+	_ "go-lib-ffi/markdown"
+	_ "go-lib-ffi/search"
+	_ "go-lib-ffi/textutil"
+)
+
+func TestCapabilities(t *testing.T) {
+	capabilities := buildinfo.Capabilities()
+
+	known := []string{
+		"clean_html",
+		"content_fingerprint",
+		"convert_markdown",
+		"detect_language",
+		"detect_search_page",
+		"extract_between_comments",
+		"extract_canonical",
+		"extract_summary",
+		"extract_tables",
+		"group_by_domain",
+		"html_blocks",
+		"main_content",
+		"markdown_blocks",
+		"markdown_tables",
+		"minify_markdown",
+		"preview_clean",
+		"process_page",
+		"process_with_stats",
+		"reader_mode",
+		"redirect_patterns",
+		"remove_stopwords",
+		"split_sections",
+		"string_arenas",
+		"strip_markdown",
+		"tracking_params",
+		"utf16_input",
+		buildinfo.EnginePrefix + "bing",
+		buildinfo.EnginePrefix + "duckduckgo",
+		buildinfo.EnginePrefix + "google",
+		buildinfo.EnginePrefix + "lite",
+		buildinfo.EnginePrefix + "reddit",
+	}
+	for _, name := range known {
+		if !slices.Contains(capabilities, name) {
+			t.Errorf("Capabilities() failed\nExpected to contain: %s\nGot:                 %v", name, capabilities)
+		}
+	}
+
+	if !slices.IsSorted(capabilities) {
+		t.Errorf("Capabilities() failed\nExpected sorted names\nGot: %v", capabilities)
+	}
+
+	if features := buildinfo.Get().Features; !slices.Equal(features, capabilities) {
+		t.Errorf("Get() failed\nExpected Features: %v\nGot:               %v", capabilities, features)
+	}
+}
+
+func TestRegisterDeduplicates(t *testing.T) {
+	buildinfo.Register("test_feature", "test_feature")
+	buildinfo.Register("test_feature")
+
+	count := 0
+	for _, name := range buildinfo.Capabilities() {
+		if name == "test_feature" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Register() failed\nExpected: test_feature listed once\nGot:      %d times", count)
+	}
+}
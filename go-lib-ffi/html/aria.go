@@ -0,0 +1,26 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// removeAriaHidden removes every element marked aria-hidden="true",
+// content included, such as icon glyphs and visually duplicated labels
+func removeAriaHidden(node *html.Node, remove removeFunc) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if isAriaHidden(child) {
+			remove(child, RemovalRuleAriaHidden)
+		} else {
+			removeAriaHidden(child, remove)
+		}
+		child = next
+	}
+}
+
+// isAriaHidden reports whether n is an element with aria-hidden="true"
+func isAriaHidden(n *html.Node) bool {
+	return n.Type == html.ElementNode && strings.EqualFold(strings.TrimSpace(getAttr(n, "aria-hidden")), "true")
+}
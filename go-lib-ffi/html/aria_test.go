@@ -0,0 +1,50 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDropAriaHidden(t *testing.T) {
+	input := `<p><span class="icon" aria-hidden="true">★</span> Starred <span aria-hidden="TRUE">(duplicate)</span>` +
+		`<span aria-hidden="false">shown</span></p>`
+
+	tests := []struct {
+		name     string
+		opts     TextOptions
+		expected string
+	}{
+		{
+			name:     "kept by default",
+			expected: "★ Starred (duplicate)shown",
+		},
+		{
+			name:     "hidden elements dropped",
+			opts:     TextOptions{DropAriaHidden: true},
+			expected: "Starred shown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTextWithOptions(input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ExtractTextWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLDropAriaHidden(t *testing.T) {
+	input := `<div><button><svg aria-hidden="true"></svg><i class="fa fa-save" aria-hidden="true"></i>Save</button></div>`
+
+	result := CleanHTMLWithOptions(input, CleanOptions{DropAriaHidden: true, Fragment: true})
+	expected := `<div><button>Save</button></div>`
+	if result != expected {
+		t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, expected, result)
+	}
+
+	if kept := CleanHTMLWithOptions(input, CleanOptions{Fragment: true}); !strings.Contains(kept, `aria-hidden="true"`) {
+		t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: aria-hidden elements kept by default\nGot:      %q", input, kept)
+	}
+}
@@ -0,0 +1,60 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// attributeMatcher is a compiled KeepAttributes list. Matching is
+// case-insensitive; entries ending in "*" match by prefix.
+type attributeMatcher struct {
+	names    map[string]bool
+	prefixes []string
+}
+
+// compileAttributeMatcher normalizes patterns into a matcher. Cleaning
+// compiles it once per call instead of once per attribute.
+func compileAttributeMatcher(patterns []string) *attributeMatcher {
+	m := &attributeMatcher{names: make(map[string]bool, len(patterns))}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			m.prefixes = append(m.prefixes, prefix)
+		} else {
+			m.names[pattern] = true
+		}
+	}
+	return m
+}
+
+// matches reports whether the attribute key is kept
+func (m *attributeMatcher) matches(key string) bool {
+	key = strings.ToLower(key)
+	if m.names[key] {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripAttributes removes every attribute below node that keep does not match
+func stripAttributes(node *html.Node, keep *attributeMatcher) {
+	if node.Type == html.ElementNode && len(node.Attr) > 0 {
+		kept := node.Attr[:0]
+		for _, attr := range node.Attr {
+			if keep.matches(attr.Key) {
+				kept = append(kept, attr)
+			}
+		}
+		node.Attr = kept
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		stripAttributes(child, keep)
+	}
+}
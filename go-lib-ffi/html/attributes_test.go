@@ -0,0 +1,100 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCleanHTMLStripAttributes(t *testing.T) {
+	input := `<div id="main" class="card" data-id="42" data-testid="card" style="color:red">` +
+		`<a href="/x" onclick="track()" DATA-Track="1">Link</a></div>`
+
+	tests := []struct {
+		name     string
+		opts     CleanOptions
+		expected string
+	}{
+		{
+			name:     "attributes kept by default",
+			opts:     CleanOptions{Fragment: true},
+			expected: `<div id="main" class="card" data-id="42" data-testid="card" style="color:red"><a href="/x" onclick="track()" data-track="1">Link</a></div>`,
+		},
+		{
+			name:     "all attributes stripped",
+			opts:     CleanOptions{Fragment: true, StripAttributes: true},
+			expected: `<div><a>Link</a></div>`,
+		},
+		{
+			name:     "allowlisted attribute kept",
+			opts:     CleanOptions{Fragment: true, StripAttributes: true, KeepAttributes: []string{"data-id"}},
+			expected: `<div data-id="42"><a>Link</a></div>`,
+		},
+		{
+			name:     "wildcard keeps every data attribute",
+			opts:     CleanOptions{Fragment: true, StripAttributes: true, KeepAttributes: []string{"data-*", "HREF"}},
+			expected: `<div data-id="42" data-testid="card"><a href="/x" data-track="1">Link</a></div>`,
+		},
+		{
+			name:     "allowlist ignored without stripping",
+			opts:     CleanOptions{Fragment: true, KeepAttributes: []string{"data-id"}},
+			expected: `<div id="main" class="card" data-id="42" data-testid="card" style="color:red"><a href="/x" onclick="track()" data-track="1">Link</a></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCompileAttributeMatcher(t *testing.T) {
+	m := compileAttributeMatcher([]string{" ID ", "data-*", "Aria-Label"})
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "id", expected: true},
+		{key: "DATA-track", expected: true},
+		{key: "aria-label", expected: true},
+		{key: "aria-hidden", expected: false},
+		{key: "class", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if result := m.matches(tt.key); result != tt.expected {
+				t.Errorf("matches() failed\nInput:    %s\nExpected: %v\nGot:      %v", tt.key, tt.expected, result)
+			}
+		})
+	}
+}
+
+func BenchmarkCleanHTMLRepeated(b *testing.B) {
+	page := func(n int) string {
+		return fmt.Sprintf("<html><head><script>track(%d)</script></head><body><nav><a href='/'>Home</a></nav>", n) +
+			strings.Repeat(fmt.Sprintf("<div class='post' data-id='%d' style='margin:0'><h2 id='h%d'>Post</h2>"+
+				"<p>Body with <a href='/p/%d' rel='nofollow' data-track='x'>a link</a>.</p></div>", n, n, n), 20) +
+			"<footer>Copyright</footer></body></html>"
+	}
+	pages := make([]string, 16)
+	for i := range pages {
+		pages[i] = page(i)
+	}
+	opts := CleanOptions{
+		StripAttributes:       true,
+		KeepAttributes:        []string{"href", "src", "alt", "title", "data-*", "aria-*"},
+		RemoveEmptyContainers: true,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CleanHTMLWithOptions(pages[i%len(pages)], opts)
+	}
+}
@@ -0,0 +1,155 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/markdown"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("html_blocks")
+}
+
+// ParseHTMLBlocks splits the body of an HTML document into the typed blocks
+// markdown.ParseMarkdownBlocks returns, reading them straight from the DOM
+// instead of a markdown round-trip. <h1>-<h6>, <p>, <ul>/<ol>, <pre> and
+// <blockquote> map to heading, paragraph, list, code and quote blocks; text
+// sitting directly in containers such as <div> becomes a paragraph. Tables,
+// <hr> and invisible elements are skipped, as are blocks without text.
+func ParseHTMLBlocks(htmlStr string) []markdown.Block {
+	blocks := []markdown.Block{}
+	if strings.TrimSpace(htmlStr) == "" {
+		return blocks
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return blocks
+	}
+
+	add := func(block markdown.Block) {
+		if block.Text != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		var inline []*html.Node
+		flush := func() {
+			add(markdown.Block{Type: markdown.BlockParagraph, Text: inlineText(inline)})
+			inline = nil
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if !isBlockNode(child) {
+				inline = append(inline, child)
+				continue
+			}
+			flush()
+
+			switch {
+			case isElement(child, headingTags...):
+				add(markdown.Block{Type: markdown.BlockHeading, Text: extractText(child), Level: int(child.Data[1] - '0')})
+			case isElement(child, "p"):
+				add(markdown.Block{Type: markdown.BlockParagraph, Text: extractText(child)})
+			case isElement(child, "ul", "ol"):
+				var lines []string
+				htmlListLines(child, "", &lines)
+				add(markdown.Block{Type: markdown.BlockList, Text: strings.Join(lines, "\n")})
+			case isElement(child, "pre"):
+				code := strings.TrimRight(textContent(child), "\n")
+				add(markdown.Block{Type: markdown.BlockCode, Text: code, Language: codeLanguage(child)})
+			case isElement(child, "blockquote"):
+				add(markdown.Block{Type: markdown.BlockQuote, Text: strings.Join(paragraphTexts(child), "\n\n")})
+			case isElement(child, "table", "hr") || invisibleElements[child.Data]:
+				// No block of their own
+			default:
+				walk(child)
+			}
+		}
+		flush()
+	}
+
+	for _, body := range findElements(doc, "body") {
+		walk(body)
+	}
+
+	return blocks
+}
+
+// isBlockNode reports whether n starts a block of its own. <br> stays in
+// the inline run around it, and invisible elements are handled as blocks
+// so they can be skipped.
+func isBlockNode(n *html.Node) bool {
+	if n.Type != html.ElementNode || n.Data == "br" {
+		return false
+	}
+	return blockElements[n.Data] || invisibleElements[n.Data]
+}
+
+// inlineText returns the visible text of a run of sibling nodes with
+// whitespace collapsed
+func inlineText(nodes []*html.Node) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		writeVisibleText(&sb, n)
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// paragraphTexts returns the text of every block child of n, and of every
+// run of inline children between them, skipping empty ones
+func paragraphTexts(n *html.Node) []string {
+	var paragraphs []string
+	var inline []*html.Node
+	flush := func() {
+		if t := inlineText(inline); t != "" {
+			paragraphs = append(paragraphs, t)
+		}
+		inline = nil
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !isBlockNode(child) {
+			inline = append(inline, child)
+			continue
+		}
+		flush()
+		if t := extractText(child); t != "" {
+			paragraphs = append(paragraphs, t)
+		}
+	}
+	flush()
+
+	return paragraphs
+}
+
+// htmlListLines appends one line per <li> of list to lines, each prefixed
+// with indent. Nested lists follow their item, indented two more spaces,
+// like the list blocks of markdown.ParseMarkdownBlocks.
+func htmlListLines(list *html.Node, indent string, lines *[]string) {
+	for item := list.FirstChild; item != nil; item = item.NextSibling {
+		if !isElement(item, "li") {
+			continue
+		}
+
+		var text []*html.Node
+		var nested []*html.Node
+		for child := item.FirstChild; child != nil; child = child.NextSibling {
+			if isElement(child, "ul", "ol") {
+				nested = append(nested, child)
+			} else {
+				text = append(text, child)
+			}
+		}
+
+		*lines = append(*lines, indent+inlineText(text))
+		for _, sub := range nested {
+			htmlListLines(sub, indent+"  ", lines)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+
+	"go-lib-ffi/markdown"
+)
+
+func TestParseHTMLBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []markdown.Block
+	}{
+		{
+			name:     "empty",
+			input:    "  ",
+			expected: []markdown.Block{},
+		},
+		{
+			name: "mixed document",
+			input: `<html><head><title>Go</title><style>p{}</style></head><body><article>` +
+				`<h1>Getting <b>started</b></h1>` +
+				`<p>Go is a <a href="https://go.dev">language</a>` + "\n" + `that compiles <code>fast</code>.</p>` +
+				`<h2>Install</h2>` +
+				`<ul><li>Download it</li><li>Run the <em>installer</em><ol><li>Accept</li><li>Finish</li></ol></li></ul>` +
+				`<pre><code class="language-go">fmt.Println("hi")` + "\n\n" + `// done` + "\n" + `</code></pre>` +
+				`<blockquote><p>Simplicity is <i>complicated</i>.</p><p>Rob Pike</p></blockquote>` +
+				`<hr><table><tr><td>a</td></tr></table>` +
+				`<div>Loose text<br>in a div</div><script>var x = 1;</script>` +
+				`</article></body></html>`,
+			expected: []markdown.Block{
+				{Type: markdown.BlockHeading, Text: "Getting started", Level: 1},
+				{Type: markdown.BlockParagraph, Text: "Go is a language that compiles fast."},
+				{Type: markdown.BlockHeading, Text: "Install", Level: 2},
+				{Type: markdown.BlockList, Text: "Download it\nRun the installer\n  Accept\n  Finish"},
+				{Type: markdown.BlockCode, Text: "fmt.Println(\"hi\")\n\n// done", Language: "go"},
+				{Type: markdown.BlockQuote, Text: "Simplicity is complicated.\n\nRob Pike"},
+				{Type: markdown.BlockParagraph, Text: "Loose text in a div"},
+			},
+		},
+		{
+			name:  "inline quote and code without language",
+			input: `<blockquote>Just a <b>quote</b></blockquote><pre>plain code</pre><p> </p>`,
+			expected: []markdown.Block{
+				{Type: markdown.BlockQuote, Text: "Just a quote"},
+				{Type: markdown.BlockCode, Text: "plain code"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseHTMLBlocks(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseHTMLBlocks() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseHTMLBlocksMatchesMarkdown(t *testing.T) {
+	input := `<h2>Notes</h2><p>First <em>point</em>.</p><ol><li>one</li><li>two</li></ol><blockquote><p>quoted</p></blockquote>`
+
+	expected := markdown.ParseMarkdownBlocks(ConvertHTMLToMarkdown(input))
+	result := ParseHTMLBlocks(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseHTMLBlocks() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", input, expected, result)
+	}
+}
@@ -0,0 +1,69 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("extract_canonical")
+}
+
+// CanonicalLinks describes the canonical and AMP versions of a page
+type CanonicalLinks struct {
+	// Canonical is the href of <link rel="canonical">
+	Canonical string `json:"canonical"`
+
+	// AMP is the href of <link rel="amphtml">, the AMP version of the page
+	AMP string `json:"amp"`
+
+	// IsAMP reports whether the document itself is an AMP page,
+	// marked by <html amp> or <html ⚡>
+	IsAMP bool `json:"is_amp"`
+}
+
+// ExtractCanonical returns the canonical URL and the AMP URL of a document
+// from <link rel="canonical"> and <link rel="amphtml">. URLs are returned as
+// written, relative ones included; each is empty when its link is absent.
+func ExtractCanonical(htmlStr string) (canonical string, amp string) {
+	links := ExtractCanonicalLinks(htmlStr)
+	return links.Canonical, links.AMP
+}
+
+// ExtractCanonicalLinks returns the canonical and AMP links of a document
+// like ExtractCanonical, and whether the document is an AMP page itself
+func ExtractCanonicalLinks(htmlStr string) CanonicalLinks {
+	if strings.TrimSpace(htmlStr) == "" {
+		return CanonicalLinks{}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return CanonicalLinks{}
+	}
+
+	var links CanonicalLinks
+	for _, link := range findElements(doc, "link") {
+		href := strings.TrimSpace(getAttr(link, "href"))
+		if href == "" {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.ToLower(getAttr(link, "rel"))) {
+			switch {
+			case rel == "canonical" && links.Canonical == "":
+				links.Canonical = href
+			case rel == "amphtml" && links.AMP == "":
+				links.AMP = href
+			}
+		}
+	}
+
+	for _, root := range findElements(doc, "html") {
+		links.IsAMP = hasAttr(root, "amp") || hasAttr(root, "⚡")
+	}
+
+	return links
+}
@@ -0,0 +1,60 @@
+package html
+
+import "testing"
+
+func TestExtractCanonicalLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected CanonicalLinks
+	}{
+		{
+			name: "canonical and amphtml links",
+			input: `<html><head>
+				<link rel="stylesheet" href="/style.css">
+				<link rel="canonical" href="https://example.com/article">
+				<link rel="amphtml" href="https://example.com/article/amp">
+			</head><body></body></html>`,
+			expected: CanonicalLinks{Canonical: "https://example.com/article", AMP: "https://example.com/article/amp"},
+		},
+		{
+			name:     "neither link",
+			input:    `<html><head><title>Plain</title><link rel="icon" href="/favicon.ico"></head></html>`,
+			expected: CanonicalLinks{},
+		},
+		{
+			name:     "AMP page pointing to its canonical",
+			input:    `<!doctype html><html amp lang="en"><head><link rel="canonical" href="/article"></head></html>`,
+			expected: CanonicalLinks{Canonical: "/article", IsAMP: true},
+		},
+		{
+			name:     "lightning attribute marks AMP",
+			input:    `<html ⚡><head></head></html>`,
+			expected: CanonicalLinks{IsAMP: true},
+		},
+		{
+			name:     "rel tokens are case-insensitive and first link wins",
+			input:    `<link rel="Canonical" href=" /first "><link rel="canonical" href="/second"><link rel="canonical" href="">`,
+			expected: CanonicalLinks{Canonical: "/first"},
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: CanonicalLinks{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractCanonicalLinks(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractCanonicalLinks() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+
+			canonical, amp := ExtractCanonical(tt.input)
+			if canonical != tt.expected.Canonical || amp != tt.expected.AMP {
+				t.Errorf("ExtractCanonical() failed\nInput:    %s\nExpected: %q, %q\nGot:      %q, %q", tt.input, tt.expected.Canonical, tt.expected.AMP, canonical, amp)
+			}
+		})
+	}
+}
@@ -3,44 +3,200 @@ package html
 import (
 	"strings"
 
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/textutil"
+
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
+func init() {
+	buildinfo.Register("clean_html")
+}
+
+// TruncationMarker is appended to cleaned HTML that was cut short
+const TruncationMarker = "<!-- truncated -->"
+
+// CleanOptions controls optional behavior of CleanHTMLWithOptions.
+// The zero value produces the same output as CleanHTML.
+type CleanOptions struct {
+	// VoidElementStyle selects how void elements such as <br> are written.
+	// An empty or unknown value keeps the XHTML style produced by the renderer.
+	VoidElementStyle VoidElementStyle `json:"void_element_style"`
+
+	// DedupeBlocks removes <p> and <li> elements whose text repeats an earlier
+	// one (ignoring whitespace differences), such as repeated cookie notices.
+	// It is opt-in because some pages legitimately repeat content.
+	DedupeBlocks bool `json:"dedupe_blocks"`
+
+	// Fragment parses the input as a body fragment such as "<div>...</div>"
+	// and returns only the cleaned fragment, without the html, head and body
+	// wrappers a full document parse adds. Unclosed tags are still balanced.
+	Fragment bool `json:"fragment"`
+
+	// RemoveEmptyContainers removes block containers such as <div> that are
+	// left with only whitespace after noisy elements are removed
+	RemoveEmptyContainers bool `json:"remove_empty_containers"`
+
+	// KeepFigureCaptions moves the <figcaption> of each <figure> after its
+	// image and keeps it even when DedupeBlocks or RemoveEmptyContainers
+	// would remove it, so a kept figure never loses its caption
+	KeepFigureCaptions bool `json:"keep_figure_captions"`
+
+	// DropAriaHidden removes elements marked aria-hidden="true", which are
+	// usually icons or duplicated labels. It is opt-in because some sites
+	// hide real content from screen readers by mistake.
+	DropAriaHidden bool `json:"drop_aria_hidden"`
+
+	// StripAttributes removes all attributes except those matching
+	// KeepAttributes, leaving bare structural markup
+	StripAttributes bool `json:"strip_attributes"`
+
+	// KeepAttributes lists the attributes StripAttributes keeps, e.g.
+	// "href", "src" or "data-id". Matching is case-insensitive and entries
+	// ending in "*" match by prefix, so "data-*" keeps every data attribute.
+	KeepAttributes []string `json:"keep_attributes"`
+
+	// FormValues replaces form controls with their default value as text:
+	// the value of text-like <input> elements, the content of <textarea>
+	// and the selected options of <select>. Passwords, hidden inputs,
+	// checkboxes and radio buttons are dropped.
+	FormValues bool `json:"form_values"`
+
+	// BaseURL makes relative href and src attributes absolute. A <base href>
+	// in the document takes precedence, resolved against BaseURL when it is
+	// relative itself. Fragment-only links such as "#top" are left as is.
+	// Empty leaves relative links unchanged.
+	BaseURL string `json:"base_url"`
+
+	// DropFragmentLinks replaces links pointing into the same page, such as
+	// <a href="#section">, with their content. Protocol-relative URLs like
+	// "//cdn.example.com/x" always get a scheme, from <base href> or https.
+	DropFragmentLinks bool `json:"drop_fragment_links"`
+
+	// DropBlockWhitespace removes whitespace-only text between block
+	// elements, such as source indentation, to shrink the output and keep
+	// diffs stable. Whitespace that separates inline content and anything
+	// inside <pre> or <textarea> is kept.
+	DropBlockWhitespace bool `json:"drop_block_whitespace"`
+
+	// Limits rejects oversized inputs before they are parsed.
+	// The zero value sets no limits.
+	Limits textutil.Limits `json:"limits"`
+}
+
 // CleanHTML removes noisy elements from HTML content
-// It removes: script, style, nav, header, footer, aside, noscript, iframe, svg
+// It removes: script, style, nav, header, footer, aside, noscript, iframe, svg,
+// plus <wbr> elements and soft hyphens so split words stay whole
 // Returns the cleaned HTML as a string
 func CleanHTML(htmlStr string) string {
+	return CleanHTMLWithOptions(htmlStr, CleanOptions{})
+}
+
+// CleanHTMLWithOptions removes noisy elements like CleanHTML
+// with the optional behaviors enabled in opts
+func CleanHTMLWithOptions(htmlStr string, opts CleanOptions) string {
+	cleaned, _ := CleanHTMLChecked(htmlStr, opts)
+	return cleaned
+}
+
+// CleanHTMLWithBase cleans HTML like CleanHTML and resolves relative links
+// against baseURL, or against the document's own <base href> when it has one
+func CleanHTMLWithBase(htmlStr, baseURL string) string {
+	return CleanHTMLWithOptions(htmlStr, CleanOptions{BaseURL: baseURL})
+}
+
+// CleanHTMLChecked cleans HTML like CleanHTMLWithOptions and reports inputs
+// rejected by opts.Limits with a *textutil.LimitError and an empty result
+func CleanHTMLChecked(htmlStr string, opts CleanOptions) (string, error) {
 	if strings.TrimSpace(htmlStr) == "" {
-		return ""
+		return "", nil
+	}
+
+	if err := opts.Limits.Check(htmlStr); err != nil {
+		return "", err
 	}
 
 	// Parse the HTML
-	doc, err := html.Parse(strings.NewReader(htmlStr))
+	doc, err := parseHTML(htmlStr, opts.Fragment)
 	if err != nil {
 		// Return original HTML if parsing fails
-		return htmlStr
+		return htmlStr, nil
+	}
+
+	cleaned, err := cleanDocument(doc, opts)
+	if err != nil {
+		// Return original HTML if rendering fails
+		return htmlStr, nil
 	}
 
-	// Elements to remove
-	noisyElements := map[string]bool{
-		"script":   true,
-		"style":    true,
-		"nav":      true,
-		"header":   true,
-		"footer":   true,
-		"aside":    true,
-		"noscript": true,
-		"iframe":   true,
-		"svg":      true,
+	return cleaned, nil
+}
+
+// ParseHTML parses a full HTML document for use with the node-level
+// functions, so several operations can share one parse
+func ParseHTML(htmlStr string) (*html.Node, error) {
+	return html.Parse(strings.NewReader(htmlStr))
+}
+
+// CleanNode removes noisy elements below node in place, like CleanHTML
+// does for a document string. Render the node with html.Render to get
+// the cleaned markup. A nil node is left alone.
+func CleanNode(node *html.Node) {
+	if node == nil {
+		return
 	}
+	cleanTree(node, CleanOptions{})
+}
+
+// cleanDocument cleans doc in place like cleanTree and renders the result
+func cleanDocument(doc *html.Node, opts CleanOptions) (string, error) {
+	cleanTree(doc, opts)
 
+	// Render the cleaned HTML back to string using a pooled buffer
+	buf := textutil.GetBuffer()
+	defer textutil.PutBuffer(buf)
+
+	if err := html.Render(buf, doc); err != nil {
+		return "", err
+	}
+
+	if opts.VoidElementStyle == VoidElementsHTML {
+		return unselfCloseVoidElements(buf.String()), nil
+	}
+
+	return buf.String(), nil
+}
+
+// cleanTree removes noisy elements below doc in place and applies
+// the optional passes in opts
+func cleanTree(doc *html.Node, opts CleanOptions) {
+	cleanTreeWith(doc, opts, detachNode)
+}
+
+// noisyElements are removed from every cleaned document
+var noisyElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"nav":      true,
+	"header":   true,
+	"footer":   true,
+	"aside":    true,
+	"noscript": true,
+	"iframe":   true,
+	"svg":      true,
+}
+
+// cleanTreeWith cleans doc like cleanTree, removing every element through
+// remove so callers can observe the decisions
+func cleanTreeWith(doc *html.Node, opts CleanOptions, remove removeFunc) {
 	// Walk the tree and remove noisy elements
 	var removeElements func(*html.Node, *html.Node)
 	removeElements = func(node, parent *html.Node) {
 		if node.Type == html.ElementNode && noisyElements[node.Data] {
 			// Remove this node
 			if parent != nil {
-				parent.RemoveChild(node)
+				remove(node, RemovalRuleTag)
 			}
 			return
 		}
@@ -55,14 +211,69 @@ func CleanHTML(htmlStr string) string {
 
 	// Remove noisy elements from the entire document
 	removeElements(doc, nil)
+	removeWordBreaks(doc, remove)
 
-	// Render the cleaned HTML back to string
-	var sb strings.Builder
-	err = html.Render(&sb, doc)
+	if opts.KeepFigureCaptions {
+		remove = keepFigureCaptions(doc, remove)
+	}
+
+	if opts.DropAriaHidden {
+		removeAriaHidden(doc, remove)
+	}
+
+	if opts.FormValues {
+		inlineFormValues(doc)
+	}
+
+	if opts.DedupeBlocks {
+		dedupeBlocks(doc, remove)
+	}
+
+	if opts.RemoveEmptyContainers {
+		removeEmptyContainers(doc, remove)
+	}
+
+	resolveProtocolRelative(doc)
+	if opts.DropFragmentLinks {
+		dropFragmentLinks(doc)
+	}
+	if opts.BaseURL != "" {
+		resolveRelativeLinks(doc, opts.BaseURL)
+	}
+
+	if opts.StripAttributes {
+		stripAttributes(doc, compileAttributeMatcher(opts.KeepAttributes))
+	}
+
+	if opts.DropBlockWhitespace {
+		dropBlockWhitespace(doc)
+	}
+}
+
+// CleanHTMLLimited cleans HTML like CleanHTML and truncates the output to at most
+// maxBytes, appending TruncationMarker when anything was cut.
+// A maxBytes of 0 or less disables truncation.
+func CleanHTMLLimited(htmlStr string, maxBytes int) (string, textutil.TruncateReport) {
+	return textutil.TruncateBytes(CleanHTML(htmlStr), maxBytes, TruncationMarker)
+}
+
+// parseHTML parses htmlStr as a full document, or as a fragment in the context
+// of <body> when fragment is set. Fragment nodes are attached to a bare document
+// node, which renders only its children.
+func parseHTML(htmlStr string, fragment bool) (*html.Node, error) {
+	if !fragment {
+		return html.Parse(strings.NewReader(htmlStr))
+	}
+
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(htmlStr), context)
 	if err != nil {
-		// Return original HTML if rendering fails
-		return htmlStr
+		return nil, err
 	}
 
-	return sb.String()
+	doc := &html.Node{Type: html.DocumentNode}
+	for _, node := range nodes {
+		doc.AppendChild(node)
+	}
+	return doc, nil
 }
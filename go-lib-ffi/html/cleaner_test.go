@@ -1,8 +1,15 @@
 package html
 
 import (
+	"errors"
+	"os"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"go-lib-ffi/textutil"
+
+	"golang.org/x/net/html"
 )
 
 func TestCleanHTML(t *testing.T) {
@@ -75,3 +82,225 @@ func normalizeHTML(h string) string {
 	// This is a very basic normalization
 	return strings.TrimSpace(h)
 }
+
+func TestCleanHTMLFragment(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		document string
+		fragment string
+	}{
+		{
+			name:     "single div",
+			input:    "<div><p>Hello</p></div>",
+			document: "<html><head></head><body><div><p>Hello</p></div></body></html>",
+			fragment: "<div><p>Hello</p></div>",
+		},
+		{
+			name:     "noisy elements removed",
+			input:    "<div><script>x()</script><p>Keep</p></div><footer>Bye</footer>",
+			document: "<html><head></head><body><div><p>Keep</p></div></body></html>",
+			fragment: "<div><p>Keep</p></div>",
+		},
+		{
+			name:     "unbalanced tags closed",
+			input:    "<div><p>One<p>Two",
+			document: "<html><head></head><body><div><p>One</p><p>Two</p></div></body></html>",
+			fragment: "<div><p>One</p><p>Two</p></div>",
+		},
+		{
+			name:     "stray closing tag ignored",
+			input:    "<span>Text</span></div>tail",
+			document: "<html><head></head><body><span>Text</span>tail</body></html>",
+			fragment: "<span>Text</span>tail",
+		},
+		{
+			name:     "multiple top-level nodes",
+			input:    "Intro <b>bold</b><p>Para</p>",
+			document: "<html><head></head><body>Intro <b>bold</b><p>Para</p></body></html>",
+			fragment: "Intro <b>bold</b><p>Para</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := CleanHTML(tt.input)
+			if document != tt.document {
+				t.Errorf("CleanHTML() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.document, document)
+			}
+
+			fragment := CleanHTMLWithOptions(tt.input, CleanOptions{Fragment: true})
+			if fragment != tt.fragment {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.fragment, fragment)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLChecked(t *testing.T) {
+	bomb := strings.Repeat("<div><span>x</span></div>", 50000)
+
+	tests := []struct {
+		name     string
+		input    string
+		limits   textutil.Limits
+		expected string
+		err      error
+	}{
+		{
+			name:     "no limits",
+			input:    "<p>Hello</p><script>x()</script>",
+			expected: "<html><head></head><body><p>Hello</p></body></html>",
+		},
+		{
+			name:     "within limits",
+			input:    "<p>Hello</p>",
+			limits:   textutil.Limits{MaxInputBytes: 1024, MaxNodes: 10},
+			expected: "<html><head></head><body><p>Hello</p></body></html>",
+		},
+		{
+			name:   "oversized input rejected",
+			input:  bomb,
+			limits: textutil.Limits{MaxInputBytes: 1 << 20},
+			err:    textutil.ErrInputTooLarge,
+		},
+		{
+			name:   "too many nodes rejected",
+			input:  bomb,
+			limits: textutil.Limits{MaxNodes: 10000},
+			err:    textutil.ErrTooManyNodes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CleanHTMLChecked(tt.input, CleanOptions{Limits: tt.limits})
+			if result != tt.expected || !errors.Is(err, tt.err) {
+				t.Errorf("CleanHTMLChecked() failed\nExpected: %q, %v\nGot:      %q, %v", tt.expected, tt.err, result, err)
+			}
+		})
+	}
+}
+
+func TestNodeAPIComposition(t *testing.T) {
+	input := "<html><head><title>Page</title></head><body><nav>Menu</nav>" +
+		"<main><h1>Heading</h1><p>Body <b>text</b></p></main><footer>Bye</footer></body></html>"
+
+	doc, err := ParseHTML(input)
+	if err != nil {
+		t.Fatalf("ParseHTML() failed: %v", err)
+	}
+
+	// Text of the full tree, before cleaning
+	if result, expected := ExtractTextFromNode(doc), ExtractText(input); result != expected {
+		t.Errorf("ExtractTextFromNode() failed\nExpected: %q\nGot:      %q", expected, result)
+	}
+
+	CleanNode(doc)
+
+	var sb strings.Builder
+	if err := html.Render(&sb, doc); err != nil {
+		t.Fatalf("html.Render() failed: %v", err)
+	}
+	if expected := CleanHTML(input); sb.String() != expected {
+		t.Errorf("CleanNode() failed\nExpected: %s\nGot:      %s", expected, sb.String())
+	}
+
+	// Text of the same tree after cleaning drops the navigation and footer
+	if result, expected := ExtractTextFromNode(doc), "Heading Body text"; result != expected {
+		t.Errorf("ExtractTextFromNode() failed\nExpected: %q\nGot:      %q", expected, result)
+	}
+
+	// A nil tree is ignored instead of panicking
+	CleanNode(nil)
+	if result := ExtractTextFromNode(nil); result != "" {
+		t.Errorf("ExtractTextFromNode() failed\nInput:    nil\nExpected: %q\nGot:      %q", "", result)
+	}
+}
+
+func TestCleanHTMLLimited(t *testing.T) {
+	input := "<html><body><p>Grüße aus Köln, 世界</p></body></html>"
+	full := CleanHTML(input)
+
+	result, report := CleanHTMLLimited(input, 0)
+	if result != full || report.Truncated {
+		t.Errorf("CleanHTMLLimited() with no limit should match CleanHTML, got %q (%+v)", result, report)
+	}
+
+	for max := len(TruncationMarker) + 1; max < len(full); max++ {
+		result, report := CleanHTMLLimited(input, max)
+		if len(result) > max {
+			t.Fatalf("CleanHTMLLimited(max=%d) output too long: %d bytes", max, len(result))
+		}
+		if !utf8.ValidString(result) {
+			t.Fatalf("CleanHTMLLimited(max=%d) split a rune: %q", max, result)
+		}
+		if !strings.HasSuffix(result, TruncationMarker) {
+			t.Fatalf("CleanHTMLLimited(max=%d) missing marker: %q", max, result)
+		}
+		kept := len(result) - len(TruncationMarker)
+		if !report.Truncated || report.CutBytes != len(full)-kept || report.OriginalBytes != len(full) {
+			t.Fatalf("CleanHTMLLimited(max=%d) report mismatch: %+v", max, report)
+		}
+	}
+}
+
+func BenchmarkCleanHTML(b *testing.B) {
+	input := "<html><head><style>body { color: red; }</style><script>var x = 1;</script></head><body>" +
+		"<nav><ul><li><a href='/'>Home</a></li><li><a href='/about'>About</a></li></ul></nav>" +
+		strings.Repeat("<article><h2>Heading</h2><p>Paragraph with <b>bold</b> and <a href='/x'>a link</a>.</p></article>", 50) +
+		"<footer>Copyright</footer></body></html>"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CleanHTML(input)
+	}
+}
+
+func FuzzCleanHTML(f *testing.F) {
+	seeds := []string{
+		"",
+		"<p>Hello</p>",
+		"<html><head><script>x()</script></head><body><nav>Menu</nav><div><p>Text</p></div></body></html>",
+		"<table><tr><td rowspan=3 colspan=2>a</td></tr></table>",
+		"<ol start=-5><li>a<ul><li>b</li></ul></li></ol>",
+		"<div><p>unclosed<span>tags",
+		"<svg><title>x</title></svg><math><mi>y</mi></math>",
+		"<!-- start --><pre><code class=language-go>x</code></pre><!-- end -->",
+	}
+	for _, fixture := range []string{"../search/testdata/reddit_listing.html", "../search/testdata/duckduckgo_results.html"} {
+		if data, err := os.ReadFile(fixture); err == nil {
+			seeds = append(seeds, string(data))
+		}
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	opts := CleanOptions{
+		DedupeBlocks:          true,
+		RemoveEmptyContainers: true,
+		DropAriaHidden:        true,
+		StripAttributes:       true,
+		VoidElementStyle:      VoidElementsHTML,
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		CleanHTML(input)
+		CleanHTMLWithOptions(input, opts)
+		opts.Fragment = true
+		CleanHTMLWithOptions(input, opts)
+		opts.Fragment = false
+		ConvertHTMLToMarkdown(input)
+		ConvertHTMLToMarkdownWithOptions(input, ConvertOptions{
+			LinkStyle:         LinkStyleReferenced,
+			LinkTitles:        true,
+			AbbrExpansion:     true,
+			InferCodeLanguage: true,
+			MaxListItems:      2,
+		})
+		ExtractTables(input)
+		ExtractMainContent(input)
+		ProcessPage(input)
+	})
+}
@@ -0,0 +1,119 @@
+package html
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	goPackageClause = regexp.MustCompile(`(?m)^package [a-z_][a-z0-9_]*\s*$`)
+	goFuncDecl      = regexp.MustCompile(`(?m)^func (\([^)]*\) )?[A-Za-z_]\w*\(`)
+	pythonDef       = regexp.MustCompile(`(?m)^\s*(async )?def \w+\(.*\)( -> .+)?:\s*$`)
+	pythonImport    = regexp.MustCompile(`(?m)^(from [\w.]+ )?import \w[\w., ]*$`)
+)
+
+// shellCommands lists commands that commonly start lines of shell snippets
+var shellCommands = map[string]bool{
+	"apt": true, "apt-get": true, "brew": true, "cd": true, "chmod": true,
+	"cp": true, "curl": true, "docker": true, "echo": true, "export": true,
+	"git": true, "kubectl": true, "ls": true, "make": true, "mkdir": true,
+	"mv": true, "npm": true, "npx": true, "pip": true, "pnpm": true,
+	"rm": true, "sudo": true, "wget": true, "yarn": true,
+}
+
+// inferCodeLanguages adds a "language-*" class to every <pre> whose language
+// is not declared, when detectCodeLanguage recognizes its content
+func inferCodeLanguages(doc *html.Node) {
+	for _, pre := range findElements(doc, "pre") {
+		if hasCodeLanguage(pre) {
+			continue
+		}
+
+		lang := detectCodeLanguage(textContent(pre))
+		if lang == "" {
+			continue
+		}
+		setAttr(pre, "class", strings.TrimSpace(getAttr(pre, "class")+" language-"+lang))
+	}
+}
+
+// hasCodeLanguage reports whether pre or a <code> inside it declares a
+// language with a "language-*" or "lang-*" class
+func hasCodeLanguage(pre *html.Node) bool {
+	return codeLanguage(pre) != ""
+}
+
+// codeLanguage returns the language declared by a "language-*" or "lang-*"
+// class on pre or a <code> inside it, or ""
+func codeLanguage(pre *html.Node) string {
+	for _, n := range append([]*html.Node{pre}, findElements(pre, "code")...) {
+		for _, class := range strings.Fields(getAttr(n, "class")) {
+			for _, prefix := range []string{"language-", "lang-"} {
+				if lang, ok := strings.CutPrefix(class, prefix); ok && lang != "" {
+					return lang
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// detectCodeLanguage guesses the language of a code snippet from patterns
+// that rarely occur elsewhere. It recognizes JSON, Go, Python and shell
+// commands and returns an empty string when unsure.
+func detectCodeLanguage(code string) string {
+	trimmed := strings.TrimSpace(code)
+	if trimmed == "" {
+		return ""
+	}
+
+	switch {
+	case (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)):
+		return "json"
+	case goPackageClause.MatchString(trimmed),
+		goFuncDecl.MatchString(trimmed) && strings.Contains(trimmed, "{"):
+		return "go"
+	case pythonDef.MatchString(trimmed), pythonImport.MatchString(trimmed):
+		return "python"
+	case isShellSnippet(trimmed):
+		return "shell"
+	}
+	return ""
+}
+
+// isShellSnippet reports whether code starts with a shebang or every command
+// line starts with a "$ " prompt or a well-known command.
+// Comment lines and continuation lines are ignored.
+func isShellSnippet(code string) bool {
+	if strings.HasPrefix(code, "#!/bin/sh") || strings.HasPrefix(code, "#!/bin/bash") ||
+		strings.HasPrefix(code, "#!/usr/bin/env bash") {
+		return true
+	}
+
+	commands := 0
+	continued := false
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimSpace(line)
+
+		// Lines continuing a command after a trailing backslash hold its arguments
+		wasContinued := continued
+		continued = strings.HasSuffix(line, "\\")
+		if wasContinued || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "$ ") {
+			commands++
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if !shellCommands[fields[0]] {
+			return false
+		}
+		commands++
+	}
+	return commands > 0
+}
@@ -0,0 +1,83 @@
+package html
+
+import "testing"
+
+func TestDetectCodeLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{name: "json object", code: "{\n  \"name\": \"go\",\n  \"stars\": 120000\n}", expected: "json"},
+		{name: "json array", code: `[{"id": 1}, {"id": 2}]`, expected: "json"},
+		{name: "invalid json", code: "{ name: go }", expected: ""},
+		{name: "go file", code: "package main\n\nfunc main() {}", expected: "go"},
+		{name: "go function", code: "func add(a, b int) int {\n\treturn a + b\n}", expected: "go"},
+		{name: "python function", code: "def greet(name):\n    print(name)", expected: "python"},
+		{name: "python import", code: "import os\nprint(os.getcwd())", expected: "python"},
+		{name: "shell commands", code: "npm install\nnpm run build", expected: "shell"},
+		{name: "shell prompt", code: "$ ./configure\n$ make install", expected: "shell"},
+		{name: "shell continuation", code: "curl https://example.com \\\n  -H 'Accept: text/html' \\\n  -o page.html", expected: "shell"},
+		{name: "shebang", code: "#!/bin/bash\nset -e\nfoo", expected: "shell"},
+		{name: "prose", code: "Install the package and run it.", expected: ""},
+		{name: "mixed commands and output", code: "git status\nOn branch main", expected: ""},
+		{name: "empty", code: "  ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := detectCodeLanguage(tt.code)
+			if result != tt.expected {
+				t.Errorf("detectCodeLanguage() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.code, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertInferCodeLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		infer    bool
+		expected string
+	}{
+		{
+			name:     "json inferred",
+			input:    "<pre><code>{\"ok\": true}</code></pre>",
+			infer:    true,
+			expected: "```json\n{\"ok\": true}\n```",
+		},
+		{
+			name:     "shell inferred",
+			input:    "<pre>sudo apt-get install git\ngit --version</pre>",
+			infer:    true,
+			expected: "```shell\nsudo apt-get install git\ngit --version\n```",
+		},
+		{
+			name:     "declared language kept",
+			input:    "<pre><code class=\"language-js\">{\"ok\": true}</code></pre>",
+			infer:    true,
+			expected: "```js\n{\"ok\": true}\n```",
+		},
+		{
+			name:     "unknown content left without info string",
+			input:    "<pre><code>Hello, world</code></pre>",
+			infer:    true,
+			expected: "```\nHello, world\n```",
+		},
+		{
+			name:     "disabled by default",
+			input:    "<pre><code>{\"ok\": true}</code></pre>",
+			expected: "```\n{\"ok\": true}\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{InferCodeLanguage: tt.infer})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
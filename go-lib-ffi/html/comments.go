@@ -0,0 +1,66 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("extract_between_comments")
+}
+
+// ExtractBetweenComments returns the HTML between a comment whose text is
+// startMarker and its matching endMarker comment, e.g. the article body in
+// "<!-- article-start -->...<!-- article-end -->", ready to be passed to
+// CleanHTML. Markers are compared with surrounding whitespace trimmed and may
+// be given with or without the "<!--" and "-->" delimiters. Nested marker
+// pairs are kept in the output, so the outermost pair wins. Returns an empty
+// string when the start marker is missing or never closed.
+func ExtractBetweenComments(htmlStr, startMarker, endMarker string) string {
+	start, end := commentMarker(startMarker), commentMarker(endMarker)
+	if start == "" || end == "" {
+		return ""
+	}
+
+	z := html.NewTokenizer(strings.NewReader(htmlStr))
+	offset, contentStart, depth := 0, -1, 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return ""
+		}
+
+		tokenStart := offset
+		offset += len(z.Raw())
+		if tt != html.CommentToken {
+			continue
+		}
+
+		text := strings.TrimSpace(string(z.Text()))
+		switch {
+		// With identical markers every other occurrence closes the pair
+		case text == end && depth > 0:
+			depth--
+			if depth == 0 {
+				return htmlStr[contentStart:tokenStart]
+			}
+		case text == start:
+			if depth == 0 {
+				contentStart = offset
+			}
+			depth++
+		}
+	}
+}
+
+// commentMarker returns the text of a comment marker without the
+// "<!--" and "-->" delimiters and surrounding whitespace
+func commentMarker(marker string) string {
+	marker = strings.TrimSpace(marker)
+	marker = strings.TrimPrefix(marker, "<!--")
+	marker = strings.TrimSuffix(marker, "-->")
+	return strings.TrimSpace(marker)
+}
@@ -0,0 +1,86 @@
+package html
+
+import "testing"
+
+func TestExtractBetweenComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		start    string
+		end      string
+		expected string
+	}{
+		{
+			name:     "markers present",
+			input:    `<nav>Menu</nav><!-- article-start --><h1>Title</h1><p>Body</p><!-- article-end --><footer>F</footer>`,
+			start:    "article-start",
+			end:      "article-end",
+			expected: `<h1>Title</h1><p>Body</p>`,
+		},
+		{
+			name:     "markers given with delimiters",
+			input:    `<div><!--article-start--><p>Body</p><!--  article-end  --></div>`,
+			start:    "<!-- article-start -->",
+			end:      "<!-- article-end -->",
+			expected: `<p>Body</p>`,
+		},
+		{
+			name:     "nested markers keep the outer pair",
+			input:    `<!-- start --><p>A</p><!-- start --><p>B</p><!-- end --><p>C</p><!-- end --><p>D</p>`,
+			start:    "start",
+			end:      "end",
+			expected: `<p>A</p><!-- start --><p>B</p><!-- end --><p>C</p>`,
+		},
+		{
+			name:     "identical start and end markers",
+			input:    `<p>A</p><!-- body --><p>B</p><!-- body --><p>C</p>`,
+			start:    "body",
+			end:      "body",
+			expected: `<p>B</p>`,
+		},
+		{
+			name:     "markers inside scripts are ignored",
+			input:    `<script>var s = "<!-- start -->";</script><!-- start --><p>Real</p><!-- end -->`,
+			start:    "start",
+			end:      "end",
+			expected: `<p>Real</p>`,
+		},
+		{
+			name:     "end before start is ignored",
+			input:    `<!-- end --><!-- start --><p>A</p><!-- end -->`,
+			start:    "start",
+			end:      "end",
+			expected: `<p>A</p>`,
+		},
+		{
+			name:     "missing start marker",
+			input:    `<p>A</p><!-- article-end -->`,
+			start:    "article-start",
+			end:      "article-end",
+			expected: "",
+		},
+		{
+			name:     "missing end marker",
+			input:    `<!-- article-start --><p>A</p>`,
+			start:    "article-start",
+			end:      "article-end",
+			expected: "",
+		},
+		{
+			name:     "empty markers",
+			input:    `<!----><p>A</p><!---->`,
+			start:    "",
+			end:      "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractBetweenComments(tt.input, tt.start, tt.end)
+			if result != tt.expected {
+				t.Errorf("ExtractBetweenComments() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
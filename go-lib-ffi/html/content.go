@@ -0,0 +1,135 @@
+package html
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("main_content")
+}
+
+// DefaultMinContentChars is the shortest main content, in characters of
+// visible text, ExtractMainContent returns before falling back to the body
+const DefaultMinContentChars = 200
+
+// MainContentOptions controls optional behavior of ExtractMainContentWithOptions.
+// The zero value produces the same output as ExtractMainContent.
+type MainContentOptions struct {
+	// MinContentChars is the number of visible text characters the extracted
+	// element must have. Shorter results, such as the message of an error
+	// page, fall back to the whole cleaned body.
+	// 0 means DefaultMinContentChars; a negative value disables the check.
+	MinContentChars int `json:"min_content_chars"`
+}
+
+// contentCandidateTags lists the elements scored as main content containers
+var contentCandidateTags = []string{"div", "section", "td"}
+
+// ExtractMainContent returns the inner HTML of the element holding the main
+// content of a document, after the cleaning done by CleanHTML.
+// The first <main> or <article> is used when present; otherwise the container
+// with the most paragraph text wins. Content shorter than
+// DefaultMinContentChars falls back to the whole cleaned body.
+func ExtractMainContent(htmlStr string) string {
+	return ExtractMainContentWithOptions(htmlStr, MainContentOptions{})
+}
+
+// ExtractMainContentWithOptions extracts the main content like ExtractMainContent
+// with the behaviors selected in opts
+func ExtractMainContentWithOptions(htmlStr string, opts MainContentOptions) string {
+	content := mainContentNode(htmlStr, opts)
+	if content == nil {
+		return ""
+	}
+	return renderChildren(content)
+}
+
+// ExtractMainContentHTML returns the main content like ExtractMainContent
+// but as the outer HTML of the chosen element, so the <main>, <article> or
+// container tag itself is kept. When the document falls back to the whole
+// body, only the body's content is returned.
+func ExtractMainContentHTML(htmlStr string) string {
+	content := mainContentNode(htmlStr, MainContentOptions{})
+	if content == nil {
+		return ""
+	}
+	if isElement(content, "body") {
+		return renderChildren(content)
+	}
+
+	var sb strings.Builder
+	if err := html.Render(&sb, content); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// mainContentNode parses and cleans htmlStr and returns the element holding
+// its main content, the body when none qualifies, or nil for empty input
+func mainContentNode(htmlStr string, opts MainContentOptions) *html.Node {
+	if strings.TrimSpace(htmlStr) == "" {
+		return nil
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return nil
+	}
+
+	cleanTree(doc, CleanOptions{})
+
+	bodies := findElements(doc, "body")
+	if len(bodies) == 0 {
+		return nil
+	}
+	body := bodies[0]
+
+	minChars := opts.MinContentChars
+	if minChars == 0 {
+		minChars = DefaultMinContentChars
+	}
+
+	content := findMainContent(body)
+	if content == nil || utf8.RuneCountInString(extractText(content)) < minChars {
+		content = body
+	}
+	return content
+}
+
+// findMainContent returns the first <main> or <article> below body, or else
+// the candidate container whose direct <p> children hold the most text.
+// Returns nil when no element qualifies.
+func findMainContent(body *html.Node) *html.Node {
+	if landmarks := findElements(body, "main", "article"); len(landmarks) > 0 {
+		return landmarks[0]
+	}
+
+	var best *html.Node
+	bestScore := 0
+	for _, candidate := range findElements(body, contentCandidateTags...) {
+		score := 0
+		for child := candidate.FirstChild; child != nil; child = child.NextSibling {
+			if isElement(child, "p") {
+				score += utf8.RuneCountInString(extractText(child))
+			}
+		}
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best
+}
+
+// renderChildren renders the children of n as trimmed HTML
+func renderChildren(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		_ = html.Render(&sb, child)
+	}
+	return strings.TrimSpace(sb.String())
+}
@@ -0,0 +1,127 @@
+package html
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtractMainContent(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Go makes it easy to build simple, reliable software. ", 3) + "</p>"
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     MainContentOptions
+		expected string
+	}{
+		{
+			name:     "article extracted",
+			input:    `<body><nav>Menu</nav><div class="links">Links</div><article><h1>Title</h1>` + paragraph + paragraph + `</article><footer>Footer</footer></body>`,
+			expected: `<h1>Title</h1>` + paragraph + paragraph,
+		},
+		{
+			name:     "container with most paragraph text extracted",
+			input:    `<body><div id="teaser"><p>Short teaser.</p></div><div id="story">` + paragraph + paragraph + `</div></body>`,
+			expected: paragraph + paragraph,
+		},
+		{
+			name:     "sparse page falls back to body",
+			input:    `<body><div class="header-text">Oops</div><article><p>Page not found.</p></article><p>Try the search.</p></body>`,
+			expected: `<div class="header-text">Oops</div><article><p>Page not found.</p></article><p>Try the search.</p>`,
+		},
+		{
+			name:     "lower threshold keeps small content",
+			input:    `<body><div class="header-text">Oops</div><article><p>Page not found.</p></article></body>`,
+			opts:     MainContentOptions{MinContentChars: 10},
+			expected: `<p>Page not found.</p>`,
+		},
+		{
+			name:     "negative threshold disables the check",
+			input:    `<body><p>Intro</p><main><p>Hi</p></main></body>`,
+			opts:     MainContentOptions{MinContentChars: -1},
+			expected: `<p>Hi</p>`,
+		},
+		{
+			name:     "page without candidates returns body",
+			input:    `<body><p>Just text.</p><script>x()</script></body>`,
+			expected: `<p>Just text.</p>`,
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMainContentWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ExtractMainContentWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractMainContentHTML(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/article.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	sidebarPage := `<html><body><div class="sidebar"><p>Subscribe to our newsletter for weekly tips.</p></div>` +
+		`<main id="content"><h1>Title</h1><p>` + strings.Repeat("Main content text. ", 20) + `</p></main></body></html>`
+
+	tests := []struct {
+		name      string
+		input     string
+		prefix    string
+		contains  []string
+		forbidden []string
+	}{
+		{
+			name:      "article fixture",
+			input:     string(fixture),
+			prefix:    `<article class="post"`,
+			contains:  []string{"<h1 class=\"post-title\">Understanding Go Slices</h1>", "<figcaption>", "</article>"},
+			forbidden: []string{"Popular posts", "Archive", "cookie", "<script"},
+		},
+		{
+			name:      "div sidebar left out",
+			input:     sidebarPage,
+			prefix:    `<main id="content"><h1>Title</h1>`,
+			contains:  []string{"Main content text.", "</main>"},
+			forbidden: []string{"newsletter"},
+		},
+		{
+			name:      "body fallback without wrapper",
+			input:     `<html><body><p>Short page.</p></body></html>`,
+			prefix:    `<p>Short page.</p>`,
+			contains:  []string{"Short page."},
+			forbidden: []string{"<body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractMainContentHTML(tt.input)
+			if !strings.HasPrefix(result, tt.prefix) {
+				t.Errorf("ExtractMainContentHTML() failed\nExpected prefix: %q\nGot:             %q", tt.prefix, result)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(result, want) {
+					t.Errorf("ExtractMainContentHTML() failed\nExpected to contain: %q\nGot:                 %s", want, result)
+				}
+			}
+			for _, unwanted := range tt.forbidden {
+				if strings.Contains(result, unwanted) {
+					t.Errorf("ExtractMainContentHTML() failed\nExpected not to contain: %q\nGot:                     %s", unwanted, result)
+				}
+			}
+		})
+	}
+
+	if result := ExtractMainContentHTML(""); result != "" {
+		t.Errorf("ExtractMainContentHTML() failed\nInput:    \"\"\nExpected: \"\"\nGot:      %q", result)
+	}
+}
@@ -3,28 +3,327 @@ package html
 import (
 	"strings"
 
-	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/markdown"
+	"go-lib-ffi/textutil"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("convert_markdown")
+}
+
+// LinkStyle selects how ConvertHTMLToMarkdownWithOptions writes links
+type LinkStyle string
+
+const (
+	// LinkStyleInline writes links as [text](url) (the default)
+	LinkStyleInline LinkStyle = "inline"
+
+	// LinkStyleReferenced writes links as [text][1] and lists every distinct
+	// URL once in a reference block, e.g. "[1]: url", at the end of the document
+	LinkStyleReferenced LinkStyle = "referenced"
 )
 
+// ConvertOptions controls optional behavior of ConvertHTMLToMarkdownWithOptions.
+// The zero value produces the same output as ConvertHTMLToMarkdown.
+type ConvertOptions struct {
+	// LinksAsFootnotes renders links as "text[1]" and lists their URLs in a
+	// numbered reference block at the end of the document.
+	// Identical URLs share the same footnote number.
+	LinksAsFootnotes bool `json:"links_as_footnotes"`
+
+	// LinkStyle selects inline or reference-style links.
+	// An empty value behaves like LinkStyleInline; LinksAsFootnotes takes precedence.
+	LinkStyle LinkStyle `json:"link_style"`
+
+	// DropFragmentLinks writes links pointing into the same page, such as
+	// <a href="#section">, as plain text. Protocol-relative URLs like
+	// "//cdn.example.com/x" always get a scheme, from <base href> or https.
+	DropFragmentLinks bool `json:"drop_fragment_links"`
+
+	// CollapseRepeatedLinks keeps one link of a run of identical adjacent
+	// links, same URL and text with only whitespace between them, such as
+	// the repeated "[Home](/)" links navigation leftovers produce
+	CollapseRepeatedLinks bool `json:"collapse_repeated_links"`
+
+	// LinkTitles writes the title attribute of links moved to the reference
+	// block by LinksAsFootnotes or LinkStyleReferenced, e.g. [1]: url "title".
+	// Inline links always keep their title as [text](url "title").
+	LinkTitles bool `json:"link_titles"`
+
+	// MarkDelimiter wraps <mark> content, e.g. "==" for "==text==".
+	// Highlight syntax is not universal, so by default the text is kept plain.
+	MarkDelimiter string `json:"mark_delimiter"`
+
+	// AbbrExpansion appends the title of <abbr title="..."> after its text,
+	// e.g. "HTML (HyperText Markup Language)". By default the title is dropped.
+	AbbrExpansion bool `json:"abbr_expansion"`
+
+	// DropDecorativeImages omits images with an empty alt attribute,
+	// which carry no content for text consumers. Images with alt text
+	// or without an alt attribute are kept.
+	DropDecorativeImages bool `json:"drop_decorative_images"`
+
+	// KeepImageDimensions writes the width and height of images in the
+	// attribute syntax some renderers support, e.g. ![alt](url){width=100}.
+	// It is off by default because the syntax is not standard markdown.
+	KeepImageDimensions bool `json:"keep_image_dimensions"`
+
+	// PreserveRawHTML lists tags, e.g. "kbd", "sub" and "sup", that have no
+	// markdown equivalent and are copied into the output as HTML, content
+	// included, instead of being converted
+	PreserveRawHTML []string `json:"preserve_raw_html"`
+
+	// PreserveStyledSpans copies <span> elements with a style attribute,
+	// e.g. <span style="color:red">, into the output as HTML for renderers
+	// that support it. Spans without a style are unwrapped to their text.
+	PreserveStyledSpans bool `json:"preserve_styled_spans"`
+
+	// DropCollapsedDetails removes <details> elements that are not marked
+	// open, summary included, since their content is hidden by default.
+	// Otherwise the summary is written in bold followed by the body.
+	DropCollapsedDetails bool `json:"drop_collapsed_details"`
+
+	// TagHandlers maps tag names to functions producing the markdown for
+	// matching elements, replacing the built-in conversion of those tags.
+	// TimeDatetimeHandler and CiteQuoteHandler are ready-made handlers.
+	// PreserveRawHTML takes precedence for tags listed in both.
+	TagHandlers map[string]TagHandler `json:"-"`
+
+	// InferCodeLanguage guesses the info string of code blocks without a
+	// "language-*" class from their content (JSON, Go, Python or shell).
+	// It is opt-in because a wrong guess is worse than no info string.
+	InferCodeLanguage bool `json:"infer_code_language"`
+
+	// MaxListItems keeps the first MaxListItems items of every <ul> and <ol>
+	// and replaces the rest with a paragraph after the list such as
+	// "… (+495 more)", so it is never numbered as an item.
+	// Each list, nested lists included, is limited on its own. 0 keeps all items.
+	MaxListItems int `json:"max_list_items"`
+
+	// DropNavLists removes <ul> and <ol> lists that look like navigation:
+	// at least NavListMinLinks links making up at least NavListLinkRatio of
+	// the list's text. Lists of prose items with a few links are kept.
+	DropNavLists bool `json:"drop_nav_lists"`
+
+	// NavListLinkRatio is the share of a list's text, from 0 to 1, that must
+	// be link text for DropNavLists to remove it.
+	// 0 means DefaultNavListLinkRatio.
+	NavListLinkRatio float64 `json:"nav_list_link_ratio"`
+
+	// NavListMinLinks is the number of links a list needs before DropNavLists
+	// considers it navigation. 0 means DefaultNavListMinLinks.
+	NavListMinLinks int `json:"nav_list_min_links"`
+
+	// PreserveListTypes keeps the numbering of <ol type="a">, "A", "i" and
+	// "I" lists by writing each item on its own line after a literal marker
+	// such as "a." or "iv.", since markdown lists are always numbered
+	PreserveListTypes bool `json:"preserve_list_types"`
+
+	// PreserveLineBreaks keeps <br> as markdown hard breaks (two trailing
+	// spaces) so addresses and poetry stay on separate lines when rendered.
+	// By default the trailing spaces are trimmed and the lines join up.
+	PreserveLineBreaks bool `json:"preserve_line_breaks"`
+
+	// DecodeEntities decodes HTML entities left as literal text in the
+	// markdown, such as double-escaped "&amp;nbsp;" in the source, and turns
+	// non-breaking spaces into regular ones (see markdown.DecodeEntities).
+	// Code is left untouched.
+	DecodeEntities bool `json:"decode_entities"`
+
+	// DisableTextFallback returns an empty string when the markdown
+	// conversion fails. By default the visible text of the document, as
+	// ExtractText returns it, is used instead so the content is not lost.
+	// This is the inverse of a FallbackToText option defaulting on: options
+	// are off in the zero value, so DisableTextFallback: true is what
+	// FallbackToText: false would mean.
+	DisableTextFallback bool `json:"disable_text_fallback"`
+
+	// QuoteAttribution ends blockquotes with a "— source" line built from a
+	// trailing <footer> or <cite>, linked to the blockquote's cite URL.
+	// A cite URL without such an element becomes the source itself.
+	QuoteAttribution bool `json:"quote_attribution"`
+}
+
+// convertNode runs the markdown converter on a document.
+// Tests replace it to exercise the conversion error path.
+var convertNode = (*converter.Converter).ConvertNode
+
+// TagHandler returns the markdown written in place of an element.
+// The result is inserted verbatim, so markdown syntax in it is kept.
+type TagHandler func(n *html.Node) string
+
 // ConvertHTMLToMarkdown converts HTML to markdown with consistent formatting
 // Uses default configuration which includes common markdown features
 func ConvertHTMLToMarkdown(htmlStr string) string {
+	return ConvertHTMLToMarkdownWithOptions(htmlStr, ConvertOptions{})
+}
+
+// ConvertHTMLToMarkdownWithOptions converts HTML to markdown like ConvertHTMLToMarkdown
+// with the optional behaviors enabled in opts
+func ConvertHTMLToMarkdownWithOptions(htmlStr string, opts ConvertOptions) string {
 	if strings.TrimSpace(htmlStr) == "" {
 		return ""
 	}
 
-	// Convert HTML to markdown
-	markdown, err := htmltomarkdown.ConvertString(htmlStr)
+	doc, err := html.Parse(strings.NewReader(htmlStr))
 	if err != nil {
-		// Return empty string if conversion fails
 		return ""
 	}
 
-	return cleanupMarkdown(markdown)
+	return convertDocument(doc, opts)
+}
+
+// convertDocument converts a parsed document to markdown.
+// The pre-conversion passes modify doc in place.
+func convertDocument(doc *html.Node, opts ConvertOptions) string {
+	// Normalize markup the converter handles inconsistently
+	normalizeImages(doc)
+	normalizeListStarts(doc)
+	removeWordBreaks(doc, detachNode)
+	italicizeFigureCaptions(doc)
+	resolveProtocolRelative(doc)
+	if opts.DropFragmentLinks {
+		dropFragmentLinks(doc)
+	}
+	if opts.CollapseRepeatedLinks {
+		collapseRepeatedLinks(doc)
+	}
+	if opts.DropNavLists {
+		dropNavLists(doc, opts.NavListLinkRatio, opts.NavListMinLinks)
+	}
+	if opts.MaxListItems > 0 {
+		truncateLists(doc, opts.MaxListItems)
+	}
+	if opts.DropDecorativeImages {
+		dropDecorativeImages(doc)
+	}
+	if opts.KeepImageDimensions {
+		annotateImageDimensions(doc)
+	}
+	if opts.DropCollapsedDetails {
+		dropCollapsedDetails(doc)
+	}
+	if opts.InferCodeLanguage {
+		inferCodeLanguages(doc)
+	}
+	if opts.QuoteAttribution {
+		attributeQuotes(doc)
+	}
+
+	refs := &linkReferences{keepTitles: opts.LinkTitles}
+	conv := newConverter(opts, refs)
+
+	// Convert HTML to markdown
+	markdown, err := convertNode(conv, doc)
+	if err != nil {
+		if opts.DisableTextFallback {
+			return ""
+		}
+		// Plain text keeps the content when conversion fails
+		return extractText(doc)
+	}
+
+	result := cleanupMarkdown(string(markdown), opts.PreserveLineBreaks, opts.DecodeEntities)
+	if block := refs.render(); block != "" {
+		result = strings.TrimSpace(result + "\n\n" + block)
+	}
+
+	return result
+}
+
+// newConverter builds a converter with the commonmark rules plus the
+// renderers required by opts. Renderers that keep per-document state
+// write into refs, so a converter must not be shared between documents.
+func newConverter(opts ConvertOptions, refs *linkReferences) *converter.Converter {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+
+	// registerFirst adds a renderer that runs before the built-in rules.
+	// Block elements stay separated from the surrounding text by blank lines.
+	registerFirst := func(tag string, render converter.HandleRenderFunc, priority int) {
+		tagType := converter.TagTypeInline
+		if blockElements[tag] {
+			tagType = converter.TagTypeBlock
+		}
+		conv.Register.RendererFor(tag, tagType, render, priority)
+	}
+
+	// Raw HTML renderers run before every other renderer so a preserved
+	// tag is never converted by the rules below
+	for _, tag := range opts.PreserveRawHTML {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		registerFirst(tag, base.RenderAsHTML, converter.PriorityEarly-2)
+	}
+
+	if opts.PreserveStyledSpans {
+		registerFirst("span", renderStyledSpan, converter.PriorityEarly-2)
+	}
+
+	for tag, handler := range opts.TagHandlers {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || handler == nil {
+			continue
+		}
+		registerFirst(tag, renderTagHandler(handler), converter.PriorityEarly-1)
+	}
+
+	// Revision markup: deletions are struck through, insertions kept as plain text
+	for _, tag := range []string{"del", "s", "strike"} {
+		conv.Register.RendererFor(tag, converter.TagTypeInline, renderDelimited("~~"), converter.PriorityEarly)
+	}
+	if opts.MarkDelimiter != "" {
+		conv.Register.RendererFor("mark", converter.TagTypeInline, renderDelimited(opts.MarkDelimiter), converter.PriorityEarly)
+	}
+
+	if opts.PreserveListTypes {
+		conv.Register.RendererFor("ol", converter.TagTypeBlock, renderTypedList, converter.PriorityEarly)
+	}
+
+	conv.Register.RendererFor("summary", converter.TagTypeBlock, renderSummary, converter.PriorityEarly)
+
+	if opts.AbbrExpansion {
+		conv.Register.RendererFor("abbr", converter.TagTypeInline, renderAbbrExpansion, converter.PriorityEarly)
+	}
+
+	switch {
+	case opts.LinksAsFootnotes:
+		conv.Register.RendererFor("a", converter.TagTypeInline, refs.renderFootnoteLink, converter.PriorityEarly)
+	case opts.LinkStyle == LinkStyleReferenced:
+		conv.Register.RendererFor("a", converter.TagTypeInline, refs.renderReferenceLink, converter.PriorityEarly)
+	}
+
+	return conv
+}
+
+// ConvertHTMLToMarkdownLimited converts HTML like ConvertHTMLToMarkdown and truncates
+// the markdown to at most maxBytes without leaving a code fence open.
+// A maxBytes of 0 or less disables truncation.
+func ConvertHTMLToMarkdownLimited(htmlStr string, maxBytes int) (string, textutil.TruncateReport) {
+	return markdown.TruncateMarkdown(ConvertHTMLToMarkdown(htmlStr), maxBytes, markdown.TruncationMarker)
 }
 
-// cleanupMarkdown performs similar cleanup to the TypeScript version
-func cleanupMarkdown(content string) string {
+// cleanupMarkdown performs similar cleanup to the TypeScript version.
+// With hardBreaks, lines ending in two or more spaces followed by another
+// line of text keep exactly two spaces as a hard line break.
+// With decodeEntities, literal HTML entities are decoded first.
+func cleanupMarkdown(content string, hardBreaks, decodeEntities bool) string {
+	if decodeEntities {
+		content = markdown.DecodeEntities(content)
+	}
+
 	// Collapse multiple blank lines
 	content = strings.ReplaceAll(content, "\r\n", "\n")
 	content = strings.ReplaceAll(content, "\r", "\n")
@@ -37,7 +336,12 @@ func cleanupMarkdown(content string) string {
 	// Remove trailing whitespace from each line
 	lines := strings.Split(content, "\n")
 	for i, line := range lines {
-		lines[i] = strings.TrimRight(line, " \t")
+		trimmed := strings.TrimRight(line, " \t")
+		if hardBreaks && trimmed != "" && strings.HasSuffix(line, "  ") &&
+			i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+			trimmed += "  "
+		}
+		lines[i] = trimmed
 	}
 	content = strings.Join(lines, "\n")
 
@@ -1,8 +1,12 @@
 package html
 
 import (
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
 )
 
 func TestConvertHTMLToMarkdown(t *testing.T) {
@@ -60,9 +64,10 @@ func TestConvertHTMLToMarkdown(t *testing.T) {
 
 func TestCleanupMarkdown(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    string
-		expected string
+		name       string
+		input      string
+		hardBreaks bool
+		expected   string
 	}{
 		{
 			name:     "collapse 3+ newlines",
@@ -79,14 +84,176 @@ func TestCleanupMarkdown(t *testing.T) {
 			input:    "  \nLine 1\n  ",
 			expected: "Line 1",
 		},
+		{
+			name:       "hard breaks kept",
+			input:      "Line 1   \nLine 2  \nLine 3\t",
+			hardBreaks: true,
+			expected:   "Line 1  \nLine 2  \nLine 3",
+		},
+		{
+			name:       "hard break before a blank line dropped",
+			input:      "Line 1  \n\nLine 2  \n",
+			hardBreaks: true,
+			expected:   "Line 1\n\nLine 2",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := cleanupMarkdown(tt.input)
+			result := cleanupMarkdown(tt.input, tt.hardBreaks, false)
 			if result != tt.expected {
 				t.Errorf("cleanupMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
 			}
 		})
 	}
 }
+
+func TestConvertPreserveLineBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		preserve bool
+		expected string
+	}{
+		{
+			name:     "address block",
+			input:    "<address>ACME Corp.<br>1 Main Street<br/>Springfield, IL 62701</address>",
+			preserve: true,
+			expected: "ACME Corp.  \n1 Main Street  \nSpringfield, IL 62701",
+		},
+		{
+			name:     "address in paragraphs",
+			input:    "<p>Ship to:</p><p>Jane Doe<br>\n  42 Elm Road<br>Portland</p><p>Thanks</p>",
+			preserve: true,
+			expected: "Ship to:\n\nJane Doe  \n42 Elm Road  \nPortland\n\nThanks",
+		},
+		{
+			name:     "trailing break dropped",
+			input:    "<p>Roses are red<br></p><p>Violets are blue</p>",
+			preserve: true,
+			expected: "Roses are red\n\nViolets are blue",
+		},
+		{
+			name:     "list item",
+			input:    "<ul><li>Office<br>Floor 3</li></ul>",
+			preserve: true,
+			expected: "- Office  \n  Floor 3",
+		},
+		{
+			name:     "disabled by default",
+			input:    "<address>ACME Corp.<br>1 Main Street</address>",
+			expected: "ACME Corp.\n1 Main Street",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{PreserveLineBreaks: tt.preserve})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertTextFallback(t *testing.T) {
+	// Simulate a converter failure
+	original := convertNode
+	convertNode = func(*converter.Converter, *html.Node, ...converter.ConvertOptionFunc) ([]byte, error) {
+		return nil, errors.New("conversion failed")
+	}
+	defer func() { convertNode = original }()
+
+	input := "<html><body><nav>Menu</nav><h1>Title</h1><p>Some <b>body</b> text.</p><script>x()</script></body></html>"
+
+	tests := []struct {
+		name     string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "falls back to plain text",
+			expected: ExtractText(input),
+		},
+		{
+			name:     "fallback disabled",
+			opts:     ConvertOptions{DisableTextFallback: true},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+
+	if result := ConvertHTMLToMarkdown(input); !strings.Contains(result, "Some body text.") {
+		t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: non-empty text\nGot:      %q", input, result)
+	}
+}
+
+func TestConvertHTMLToMarkdownLimited(t *testing.T) {
+	input := "<p>Intro</p><pre><code>line one\nline two\nline three</code></pre>"
+
+	result, report := ConvertHTMLToMarkdownLimited(input, 0)
+	if result != ConvertHTMLToMarkdown(input) || report.Truncated {
+		t.Errorf("ConvertHTMLToMarkdownLimited() with no limit should match ConvertHTMLToMarkdown, got %q", result)
+	}
+
+	result, report = ConvertHTMLToMarkdownLimited(input, 40)
+	if !report.Truncated {
+		t.Fatalf("ConvertHTMLToMarkdownLimited() expected truncation, got %q", result)
+	}
+	if len(result) > 40 {
+		t.Errorf("ConvertHTMLToMarkdownLimited() output too long: %d bytes", len(result))
+	}
+	if strings.Count(result, "```")%2 != 0 {
+		t.Errorf("ConvertHTMLToMarkdownLimited() left a fence open: %q", result)
+	}
+}
+
+func TestConvertDecodeEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "double-escaped entities decoded",
+			input:    `<p>Fish&amp;nbsp;&amp;amp;&amp;nbsp;chips &amp;#8212; &amp;#x263A;</p>`,
+			opts:     ConvertOptions{DecodeEntities: true},
+			expected: "Fish & chips \u2014 \u263a",
+		},
+		{
+			name:     "non-breaking spaces become spaces",
+			input:    `<p>10&nbsp;km&nbsp;</p><p>&nbsp;</p>`,
+			opts:     ConvertOptions{DecodeEntities: true},
+			expected: "10 km",
+		},
+		{
+			name:     "escaped markup and code kept",
+			input:    `<p>&lt;br&gt; &amp;amp;</p><pre><code>a &amp;amp; b</code></pre><p><code>&amp;nbsp;</code></p>`,
+			opts:     ConvertOptions{DecodeEntities: true},
+			expected: "&lt;br&gt; &\n\n```\na &amp; b\n```\n\n`&nbsp;`",
+		},
+		{
+			name:     "kept by default",
+			input:    `<p>Fish&amp;nbsp;&amp;amp;&amp;nbsp;chips</p>`,
+			expected: "Fish&nbsp;&amp;&nbsp;chips",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package html
+
+import "golang.org/x/net/html"
+
+// dedupeTags lists the block elements considered by dedupeBlocks
+var dedupeTags = []string{"p", "li"}
+
+// dedupeBlocks removes every <p> and <li> whose normalized text matches an
+// earlier one. Blocks without text are never treated as duplicates, and
+// blocks nested in a kept block (a <p> inside an <li>) are left alone.
+// Blocks nested in a removed block go with it and are not removed again.
+func dedupeBlocks(doc *html.Node, remove removeFunc) {
+	seen := make(map[string]bool)
+	kept := make(map[*html.Node]bool)
+	removed := make(map[*html.Node]bool)
+
+	for _, block := range findElements(doc, dedupeTags...) {
+		if hasAncestorIn(block, kept) || hasAncestorIn(block, removed) {
+			continue
+		}
+
+		text := extractText(block)
+		if text == "" {
+			continue
+		}
+
+		if seen[text] {
+			remove(block, RemovalRuleDuplicate)
+			removed[block] = true
+			continue
+		}
+		seen[text] = true
+		kept[block] = true
+	}
+}
+
+// hasAncestorIn reports whether any ancestor of n is in set
+func hasAncestorIn(n *html.Node, set map[*html.Node]bool) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if set[p] {
+			return true
+		}
+	}
+	return false
+}
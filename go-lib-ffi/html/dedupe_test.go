@@ -0,0 +1,57 @@
+package html
+
+import "testing"
+
+func TestCleanHTMLDedupeBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "exact duplicate paragraphs",
+			input:    "<p>We use cookies.</p><p>Article text.</p><p>We use cookies.</p><p>We use cookies.</p>",
+			expected: "<html><head></head><body><p>We use cookies.</p><p>Article text.</p></body></html>",
+		},
+		{
+			name:     "whitespace and markup differences are ignored",
+			input:    "<p>Subscribe  to our\nnewsletter</p><div><p><b>Subscribe</b> to our newsletter</p></div>",
+			expected: "<html><head></head><body><p>Subscribe  to our\nnewsletter</p><div></div></body></html>",
+		},
+		{
+			name:     "near duplicates are kept",
+			input:    "<p>Step 1: open the lid.</p><p>Step 2: open the lid.</p><p>step 1: open the lid.</p>",
+			expected: "<html><head></head><body><p>Step 1: open the lid.</p><p>Step 2: open the lid.</p><p>step 1: open the lid.</p></body></html>",
+		},
+		{
+			name:     "duplicate list items",
+			input:    "<ul><li>Home</li><li>About</li><li>Home</li></ul>",
+			expected: "<html><head></head><body><ul><li>Home</li><li>About</li></ul></body></html>",
+		},
+		{
+			name:     "paragraph nested in list item is kept",
+			input:    "<ul><li><p>Home</p></li><li><p>Home</p></li></ul>",
+			expected: "<html><head></head><body><ul><li><p>Home</p></li></ul></body></html>",
+		},
+		{
+			name:     "empty blocks are not duplicates",
+			input:    "<p></p><p>Text</p><p> </p>",
+			expected: "<html><head></head><body><p></p><p>Text</p><p> </p></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(tt.input, CleanOptions{DedupeBlocks: true})
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+
+	// Off by default
+	input := "<p>Repeat</p><p>Repeat</p>"
+	if result := CleanHTML(input); result != "<html><head></head><body><p>Repeat</p><p>Repeat</p></body></html>" {
+		t.Errorf("CleanHTML() should not dedupe by default, got %s", result)
+	}
+}
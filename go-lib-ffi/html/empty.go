@@ -0,0 +1,48 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// emptyContainerTags lists the block containers removed by removeEmptyContainers.
+// Inline elements are excluded because whitespace-only spans can separate words.
+var emptyContainerTags = []string{
+	"div", "section", "article", "main", "p", "blockquote",
+	"ul", "ol", "li", "dl", "dt", "dd", "figure", "figcaption",
+}
+
+// removeEmptyContainers removes block containers that hold nothing but
+// whitespace and comments, working bottom-up so a container left empty by
+// the removal of its children is removed too. Elements such as <br> or <img>
+// count as content, so their containers are kept.
+func removeEmptyContainers(node *html.Node, remove removeFunc) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		removeEmptyContainers(child, remove)
+		child = next
+	}
+
+	if node.Parent != nil && isElement(node, emptyContainerTags...) && isEmptyContainer(node) {
+		remove(node, RemovalRuleEmptyContainer)
+	}
+}
+
+// isEmptyContainer reports whether every child of n is a comment or
+// whitespace-only text
+func isEmptyContainer(n *html.Node) bool {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case html.CommentNode:
+			continue
+		case html.TextNode:
+			if strings.TrimSpace(child.Data) != "" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
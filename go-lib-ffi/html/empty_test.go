@@ -0,0 +1,46 @@
+package html
+
+import "testing"
+
+func TestCleanHTMLRemoveEmptyContainers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "nested noisy elements leave no empty div",
+			input:    "<html><body><div><nav><ul><li><script>console.log(1)</script></li></ul></nav></div><p>Keep</p></body></html>",
+			expected: "<html><head></head><body><p>Keep</p></body></html>",
+		},
+		{
+			name:     "whitespace and comments count as empty",
+			input:    "<div> \n\t<!-- ad slot --> </div><p>Text</p><section><p>&nbsp;</p></section>",
+			expected: "<html><head></head><body><p>Text</p></body></html>",
+		},
+		{
+			name:     "br and img are content",
+			input:    "<div><br></div><figure><img src=\"a.png\"></figure>",
+			expected: "<html><head></head><body><div><br/></div><figure><img src=\"a.png\"/></figure></body></html>",
+		},
+		{
+			name:     "empty inline elements kept",
+			input:    "<p>a<span> </span>b</p>",
+			expected: "<html><head></head><body><p>a<span> </span>b</p></body></html>",
+		},
+		{
+			name:     "empty list items collapse the list",
+			input:    "<ul><li></li><li> </li></ul><ol><li>One</li><li></li></ol>",
+			expected: "<html><head></head><body><ol><li>One</li></ol></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(tt.input, CleanOptions{RemoveEmptyContainers: true})
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package html
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// figureCaptions moves the <figcaption> of every <figure> below doc after
+// the rest of the figure's content and returns the captions
+func figureCaptions(doc *html.Node) map[*html.Node]bool {
+	captions := make(map[*html.Node]bool)
+	for _, figure := range findElements(doc, "figure") {
+		for child := figure.FirstChild; child != nil; child = child.NextSibling {
+			if !isElement(child, "figcaption") {
+				continue
+			}
+			figure.RemoveChild(child)
+			figure.AppendChild(child)
+			captions[child] = true
+			break
+		}
+	}
+	return captions
+}
+
+// keepFigureCaptions returns a removeFunc that skips removals of figure
+// captions with text, or of anything inside them, by the duplicate and
+// empty container rules, so a kept figure keeps its caption
+func keepFigureCaptions(doc *html.Node, remove removeFunc) removeFunc {
+	captions := figureCaptions(doc)
+	for caption := range captions {
+		if extractText(caption) == "" {
+			delete(captions, caption)
+		}
+	}
+	return func(n *html.Node, rule RemovalRule) {
+		if rule == RemovalRuleDuplicate || rule == RemovalRuleEmptyContainer {
+			for p := n; p != nil; p = p.Parent {
+				if captions[p] {
+					return
+				}
+			}
+		}
+		remove(n, rule)
+	}
+}
+
+// italicizeFigureCaptions replaces the caption of every figure below doc
+// with an emphasized paragraph after the figure's content, so it renders
+// as italic text under the image. Captions without text are removed.
+func italicizeFigureCaptions(doc *html.Node) {
+	for caption := range figureCaptions(doc) {
+		figure := caption.Parent
+		if extractText(caption) == "" {
+			figure.RemoveChild(caption)
+			continue
+		}
+
+		// Paragraphs inside the caption would end the emphasis early
+		for _, p := range findElements(caption, "p") {
+			for child := p.FirstChild; child != nil; child = p.FirstChild {
+				p.RemoveChild(child)
+				p.Parent.InsertBefore(child, p)
+			}
+			p.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: " "}, p)
+			p.Parent.RemoveChild(p)
+		}
+
+		em := &html.Node{Type: html.ElementNode, Data: "em", DataAtom: atom.Em}
+		for child := caption.FirstChild; child != nil; child = caption.FirstChild {
+			caption.RemoveChild(child)
+			em.AppendChild(child)
+		}
+		para := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+		para.AppendChild(em)
+		figure.InsertBefore(para, caption)
+		figure.RemoveChild(caption)
+	}
+}
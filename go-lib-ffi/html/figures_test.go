@@ -0,0 +1,74 @@
+package html
+
+import "testing"
+
+func TestConvertFigureCaptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "caption italic under image",
+			input:    `<p>Intro</p><figure><img src="/cat.png" alt="A cat"><figcaption>A <b>sleepy</b> cat.</figcaption></figure><p>After</p>`,
+			expected: "Intro\n\n![A cat](/cat.png)\n\n*A **sleepy** cat.*\n\nAfter",
+		},
+		{
+			name:     "caption before image moved under it",
+			input:    `<figure><figcaption>Figure 1</figcaption><img src="/chart.png" alt="Chart"></figure>`,
+			expected: "![Chart](/chart.png)\n\n*Figure 1*",
+		},
+		{
+			name:     "caption paragraphs joined",
+			input:    `<figure><img src="/a.png" alt="A"><figcaption><p>First line.</p><p>Photo: Jane</p></figcaption></figure>`,
+			expected: "![A](/a.png)\n\n*First line. Photo: Jane*",
+		},
+		{
+			name:     "empty caption dropped",
+			input:    `<figure><img src="/a.png" alt="A"><figcaption> </figcaption></figure>`,
+			expected: "![A](/a.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLKeepFigureCaptions(t *testing.T) {
+	input := `<p>A sleepy cat.</p><figure><figcaption><p>A sleepy cat.</p></figcaption><img src="/cat.png" alt="Cat"></figure>` +
+		`<figure><img src="/b.png" alt="B"><figcaption><span> </span></figcaption></figure>`
+
+	tests := []struct {
+		name     string
+		opts     CleanOptions
+		expected string
+	}{
+		{
+			name: "caption lost without option",
+			opts: CleanOptions{Fragment: true, DedupeBlocks: true, RemoveEmptyContainers: true},
+			expected: `<p>A sleepy cat.</p><figure><img src="/cat.png" alt="Cat"/></figure>` +
+				`<figure><img src="/b.png" alt="B"/><figcaption><span> </span></figcaption></figure>`,
+		},
+		{
+			name: "caption kept under image",
+			opts: CleanOptions{Fragment: true, DedupeBlocks: true, RemoveEmptyContainers: true, KeepFigureCaptions: true},
+			expected: `<p>A sleepy cat.</p><figure><img src="/cat.png" alt="Cat"/><figcaption><p>A sleepy cat.</p></figcaption></figure>` +
+				`<figure><img src="/b.png" alt="B"/><figcaption><span> </span></figcaption></figure>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, tt.expected, result)
+			}
+		})
+	}
+}
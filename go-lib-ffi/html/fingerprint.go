@@ -0,0 +1,93 @@
+package html
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("content_fingerprint")
+}
+
+// boilerplateTokens lists class and id tokens marking page chrome such as
+// ads and cookie banners, which differ between copies of the same content
+var boilerplateTokens = map[string]bool{
+	"ad":            true,
+	"ads":           true,
+	"advert":        true,
+	"advertisement": true,
+	"banner":        true,
+	"cookie-banner": true,
+	"cookie-notice": true,
+	"newsletter":    true,
+	"promo":         true,
+	"related":       true,
+	"share":         true,
+	"sidebar":       true,
+	"social":        true,
+	"sponsored":     true,
+}
+
+// ContentFingerprint returns a SHA-256 hex digest of the meaningful text of a
+// document, for detecting copies of the same content. Elements removed by
+// CleanHTML and elements whose class or id marks them as ads, banners or
+// sidebars are skipped. When the document has a <main> or <article>, only its
+// text is used. The text is compared lowercased with whitespace collapsed.
+// Returns an empty string for documents without text.
+func ContentFingerprint(htmlStr string) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	cleanTree(doc, CleanOptions{})
+	removeBoilerplate(doc)
+
+	root := doc
+	if content := findElements(doc, "main", "article"); len(content) > 0 {
+		root = content[0]
+	}
+
+	text := strings.ToLower(extractText(root))
+	if text == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// removeBoilerplate removes elements whose class or id is a boilerplateTokens entry
+func removeBoilerplate(node *html.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.ElementNode && isBoilerplate(child) {
+			node.RemoveChild(child)
+		} else {
+			removeBoilerplate(child)
+		}
+		child = next
+	}
+}
+
+// isBoilerplate reports whether a class or the id of n is a boilerplateTokens entry
+func isBoilerplate(n *html.Node) bool {
+	if boilerplateTokens[strings.ToLower(getAttr(n, "id"))] {
+		return true
+	}
+	for _, class := range strings.Fields(getAttr(n, "class")) {
+		if boilerplateTokens[strings.ToLower(class)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package html
+
+import "testing"
+
+func TestContentFingerprint(t *testing.T) {
+	article := `<h1>Go 1.23 released</h1><p>The Go team is happy to announce Go 1.23.</p><p>It brings iterators.</p>`
+
+	variantA := `<html><head><title>Blog</title><script>track()</script></head><body>
+		<header><a href="/">Home</a></header>
+		<nav><a href="/a">Archive</a></nav>
+		<div class="ad">Buy now!</div>
+		<article>` + article + `</article>
+		<footer>© 2024</footer>
+	</body></html>`
+
+	variantB := `<html><body>
+		<nav><a href="/b">Other menu</a><a href="/c">More</a></nav>
+		<div id="sponsored">Try our sponsor</div>
+		<aside>Related posts</aside>
+		<article>
+			<div class="banner promo">Subscribe</div>
+			` + article + `
+		</article>
+		<div class="cookie-banner">We use cookies</div>
+	</body></html>`
+
+	variantC := `<body><div class="ads">Ad</div>` + article + `<div class="sidebar">Links</div></body>`
+
+	differentArticle := `<article><h1>Go 1.24 released</h1><p>The Go team is happy to announce Go 1.24.</p></article>`
+
+	fingerprint := ContentFingerprint(variantA)
+	if len(fingerprint) != 64 {
+		t.Fatalf("ContentFingerprint() failed\nExpected: 64 hex characters\nGot:      %q", fingerprint)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		same  bool
+	}{
+		{name: "same article with different chrome", input: variantB, same: true},
+		{name: "same article without article element", input: variantC, same: true},
+		{name: "whitespace and case differences", input: "<article><H1>GO 1.23   RELEASED</H1>\n<p>The Go team is happy to announce Go 1.23.</p> <p>It brings   iterators.</p></article>", same: true},
+		{name: "different article", input: differentArticle, same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ContentFingerprint(tt.input)
+			if (result == fingerprint) != tt.same {
+				t.Errorf("ContentFingerprint() failed\nInput:    %s\nExpected same fingerprint: %v\nGot:      %q, reference %q", tt.input, tt.same, result, fingerprint)
+			}
+		})
+	}
+}
+
+func TestContentFingerprintEmpty(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty input", input: ""},
+		{name: "whitespace input", input: "  \n "},
+		{name: "only boilerplate", input: "<nav>Menu</nav><div class='ad'>Ad</div><script>x()</script>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ContentFingerprint(tt.input); result != "" {
+				t.Errorf("ContentFingerprint() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, "", result)
+			}
+		})
+	}
+}
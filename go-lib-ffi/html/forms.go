@@ -0,0 +1,69 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// valuelessInputTypes lists <input> types whose value is not text a reader sees
+var valuelessInputTypes = map[string]bool{
+	"hidden": true, "password": true, "checkbox": true, "radio": true,
+	"file": true, "image": true, "color": true, "range": true,
+}
+
+// inlineFormValues replaces form controls with their default value as text:
+// the value attribute of text-like <input> elements, the content of
+// <textarea> and the selected options of <select>. Controls without a value
+// are removed.
+func inlineFormValues(doc *html.Node) {
+	for _, control := range findElements(doc, "input", "textarea", "select") {
+		if control.Parent == nil {
+			continue
+		}
+
+		var value string
+		switch control.Data {
+		case "input":
+			if !valuelessInputTypes[strings.ToLower(strings.TrimSpace(getAttr(control, "type")))] {
+				value = getAttr(control, "value")
+			}
+		case "textarea":
+			value = textContent(control)
+		case "select":
+			value = strings.Join(selectedOptions(control), ", ")
+		}
+
+		// Spaces keep the value apart from the text around the control
+		if strings.TrimSpace(value) != "" {
+			text := &html.Node{Type: html.TextNode, Data: " " + value + " "}
+			control.Parent.InsertBefore(text, control)
+		}
+		control.Parent.RemoveChild(control)
+	}
+}
+
+// selectedOptions returns the text of the options of a <select> marked
+// selected, or of its first option when none is, as browsers show it
+func selectedOptions(sel *html.Node) []string {
+	options := findElements(sel, "option")
+	var selected []string
+	for _, option := range options {
+		if hasAttr(option, "selected") {
+			selected = append(selected, optionText(option))
+		}
+	}
+	if len(selected) == 0 && len(options) > 0 && !hasAttr(sel, "multiple") {
+		selected = append(selected, optionText(options[0]))
+	}
+	return selected
+}
+
+// optionText returns the label shown for an <option>: its text, or its
+// label attribute when the text is empty
+func optionText(option *html.Node) string {
+	if text := strings.Join(strings.Fields(textContent(option)), " "); text != "" {
+		return text
+	}
+	return strings.TrimSpace(getAttr(option, "label"))
+}
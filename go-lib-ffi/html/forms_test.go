@@ -0,0 +1,80 @@
+package html
+
+import "testing"
+
+func TestExtractTextFormValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		enabled  bool
+		expected string
+	}{
+		{
+			name:     "text input value",
+			input:    `<label>Name <input type="text" name="name" value="Ada Lovelace"></label>`,
+			enabled:  true,
+			expected: "Name Ada Lovelace",
+		},
+		{
+			name:     "input without a type",
+			input:    `<p>Search:<input value="golang"></p>`,
+			enabled:  true,
+			expected: "Search: golang",
+		},
+		{
+			name:     "hidden, password and checkbox values skipped",
+			input:    `<form><input type="hidden" value="csrf123"><input type="password" value="secret"><input type="checkbox" value="on"> Remember me</form>`,
+			enabled:  true,
+			expected: "Remember me",
+		},
+		{
+			name:     "textarea content",
+			input:    "<p>Comment:</p><textarea name=\"c\">Looks  good\nto me</textarea>",
+			enabled:  true,
+			expected: "Comment: Looks good to me",
+		},
+		{
+			name:     "selected option",
+			input:    `<label>Country <select><option value="fr">France</option><option value="de" selected>Germany</option><option>Italy</option></select></label>`,
+			enabled:  true,
+			expected: "Country Germany",
+		},
+		{
+			name:     "first option when none is selected",
+			input:    `<select><option>Small</option><option>Large</option></select>`,
+			enabled:  true,
+			expected: "Small",
+		},
+		{
+			name:     "several selected options",
+			input:    `<select multiple><option selected>Red</option><option>Green</option><option selected label="Blue"></option></select>`,
+			enabled:  true,
+			expected: "Red, Blue",
+		},
+		{
+			name:     "disabled by default",
+			input:    `<label>Name <input value="Ada"></label><select><option>Small</option><option selected>Large</option></select>`,
+			expected: "Name SmallLarge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTextWithOptions(tt.input, TextOptions{FormValues: tt.enabled})
+			if result != tt.expected {
+				t.Errorf("ExtractTextWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLFormValues(t *testing.T) {
+	input := `<form><input type="email" value="ada@example.com"><input type="hidden" value="x">` +
+		`<textarea>Hello</textarea><select><option>A</option><option selected>B &amp; C</option></select></form>`
+	expected := "<html><head></head><body><form> ada@example.com  Hello  B &amp; C </form></body></html>"
+
+	result := CleanHTMLWithOptions(input, CleanOptions{FormValues: true})
+	if result != expected {
+		t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+	}
+}
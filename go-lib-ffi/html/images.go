@@ -0,0 +1,160 @@
+package html
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// normalizeImages collapses responsive image markup into plain <img src> elements
+// so the converter emits a single image per picture.
+// A <picture> is replaced by its fallback <img>; when that image has no src,
+// the largest srcset candidate from the image or its <source> elements is used.
+func normalizeImages(doc *html.Node) {
+	for _, picture := range findElements(doc, "picture") {
+		normalizePicture(picture)
+	}
+
+	for _, img := range findElements(doc, "img") {
+		if getAttr(img, "src") == "" {
+			if candidate := largestSrcsetCandidate(getAttr(img, "srcset")); candidate != "" {
+				setAttr(img, "src", candidate)
+			}
+		}
+		removeAttr(img, "srcset")
+	}
+}
+
+// normalizePicture replaces a <picture> element with a single <img>
+func normalizePicture(picture *html.Node) {
+	if picture.Parent == nil {
+		return
+	}
+
+	// The candidates of every <source> and of the fallback <img> compete,
+	// so the largest image wins whichever element lists it
+	var img *html.Node
+	var srcsets []string
+	for _, child := range findElements(picture, "img", "source") {
+		if child.Data == "img" {
+			if img != nil {
+				continue
+			}
+			img = child
+		}
+		if srcset := strings.TrimSpace(getAttr(child, "srcset")); srcset != "" {
+			srcsets = append(srcsets, srcset)
+		}
+	}
+
+	if img == nil {
+		img = &html.Node{Type: html.ElementNode, Data: "img"}
+	} else {
+		img.Parent.RemoveChild(img)
+	}
+
+	if getAttr(img, "src") == "" && len(srcsets) > 0 {
+		setAttr(img, "srcset", strings.Join(srcsets, ", "))
+	}
+
+	picture.Parent.InsertBefore(img, picture)
+	picture.Parent.RemoveChild(picture)
+}
+
+// largestSrcsetCandidate returns the URL of the largest candidate in a srcset
+// attribute. Width descriptors (480w) and density descriptors (2x) are compared
+// by their numeric value; a candidate without a descriptor counts as 1x.
+func largestSrcsetCandidate(srcset string) string {
+	var best string
+	bestSize := -1.0
+
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+
+		size := 1.0
+		if len(fields) > 1 {
+			descriptor := fields[len(fields)-1]
+			if len(descriptor) > 1 {
+				if value, err := strconv.ParseFloat(descriptor[:len(descriptor)-1], 64); err == nil {
+					size = value
+				}
+			}
+		}
+
+		if size > bestSize {
+			best = fields[0]
+			bestSize = size
+		}
+	}
+
+	return best
+}
+
+// dropDecorativeImages removes images marked decorative with an empty alt
+// attribute. Images without an alt attribute are kept since their role is unknown.
+func dropDecorativeImages(doc *html.Node) {
+	for _, img := range findElements(doc, "img") {
+		if hasAttr(img, "alt") && strings.TrimSpace(getAttr(img, "alt")) == "" {
+			img.Parent.RemoveChild(img)
+		}
+	}
+}
+
+// annotateImageDimensions writes the width and height attributes of every
+// image after it in attribute syntax, e.g. "{width=100 height=50}", which
+// the converter leaves in place right after the image markdown.
+// Only whole pixel values are kept; a "px" suffix is accepted.
+func annotateImageDimensions(doc *html.Node) {
+	for _, img := range findElements(doc, "img") {
+		var attrs []string
+		for _, key := range []string{"width", "height"} {
+			if value := pixelDimension(getAttr(img, key)); value != "" {
+				attrs = append(attrs, key+"="+value)
+			}
+		}
+		if len(attrs) == 0 || img.Parent == nil {
+			continue
+		}
+
+		text := &html.Node{Type: html.TextNode, Data: "{" + strings.Join(attrs, " ") + "}"}
+		img.Parent.InsertBefore(text, img.NextSibling)
+	}
+}
+
+// pixelDimension returns a width or height attribute as a whole number of
+// pixels, or an empty string when it is missing or not a positive number
+func pixelDimension(value string) string {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// lazySrcAttributes lists the attributes lazy-loading scripts read the real
+// image URL from, in order of preference
+var lazySrcAttributes = []string{"data-src", "data-lazy-src", "data-original", "data-url"}
+
+// fixLazyImages copies the real URL of lazy-loaded images into src and
+// srcset when those are missing or hold a placeholder such as a data: URI,
+// since the script that would swap them in never runs
+func fixLazyImages(doc *html.Node) {
+	for _, img := range findElements(doc, "img", "source") {
+		if src := strings.TrimSpace(getAttr(img, "src")); src == "" || strings.HasPrefix(src, "data:") {
+			for _, key := range lazySrcAttributes {
+				if value := strings.TrimSpace(getAttr(img, key)); value != "" {
+					setAttr(img, "src", value)
+					break
+				}
+			}
+		}
+		if srcset := strings.TrimSpace(getAttr(img, "data-srcset")); srcset != "" {
+			setAttr(img, "srcset", srcset)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package html
+
+import "testing"
+
+func TestConvertResponsiveImages(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "picture with two sources and fallback img",
+			input: `<picture>
+				<source srcset="cat.avif 1x, cat@2x.avif 2x" type="image/avif">
+				<source srcset="cat.webp" type="image/webp">
+				<img src="cat.jpg" alt="A cat">
+			</picture>`,
+			expected: "![A cat](cat.jpg)",
+		},
+		{
+			name:     "picture whose img has no src",
+			input:    `<picture><source srcset="small.webp 480w, large.webp 1200w"><img alt="Chart"></picture>`,
+			expected: "![Chart](large.webp)",
+		},
+		{
+			name:     "largest candidate in a later source",
+			input:    `<picture><source srcset="s.webp 480w, l.webp 1200w"><source srcset="x.jpg 2000w"><img alt="Map"></picture>`,
+			expected: "![Map](x.jpg)",
+		},
+		{
+			name:     "largest candidate in the fallback img srcset",
+			input:    `<picture><source srcset="s.webp 480w"><img srcset="m.jpg 800w, xl.jpg 1600w" alt="Map"></picture>`,
+			expected: "![Map](xl.jpg)",
+		},
+		{
+			name:     "img with srcset alone",
+			input:    `<img srcset="s.jpg 480w, l.jpg 1080w, m.jpg 800w" alt="Photo">`,
+			expected: "![Photo](l.jpg)",
+		},
+		{
+			name:     "img src wins over srcset",
+			input:    `<img src="default.png" srcset="hd.png 2x" alt="Logo">`,
+			expected: "![Logo](default.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertDropDecorativeImages(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "empty alt dropped",
+			input:    `<p>Intro <img src="spacer.gif" alt=""> text</p>`,
+			opts:     ConvertOptions{DropDecorativeImages: true},
+			expected: "Intro text",
+		},
+		{
+			name:     "whitespace alt dropped",
+			input:    `<p><img src="divider.png" alt="  "></p><p>Body</p>`,
+			opts:     ConvertOptions{DropDecorativeImages: true},
+			expected: "Body",
+		},
+		{
+			name:     "meaningful alt kept",
+			input:    `<p><img src="chart.png" alt="chart"></p>`,
+			opts:     ConvertOptions{DropDecorativeImages: true},
+			expected: "![chart](chart.png)",
+		},
+		{
+			name:     "missing alt kept",
+			input:    `<p><img src="photo.jpg"></p>`,
+			opts:     ConvertOptions{DropDecorativeImages: true},
+			expected: "![](photo.jpg)",
+		},
+		{
+			name:     "empty alt kept by default",
+			input:    `<p><img src="spacer.gif" alt=""></p>`,
+			expected: "![](spacer.gif)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertKeepImageDimensions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "width and height",
+			input:    `<p><img src="logo.png" alt="Logo" width="100" height="50"></p>`,
+			opts:     ConvertOptions{KeepImageDimensions: true},
+			expected: "![Logo](logo.png){width=100 height=50}",
+		},
+		{
+			name:     "width only with px suffix",
+			input:    `<p>Inline <img src="icon.png" alt="icon" width="16px"> icon</p>`,
+			opts:     ConvertOptions{KeepImageDimensions: true},
+			expected: "Inline ![icon](icon.png){width=16} icon",
+		},
+		{
+			name:     "linked image",
+			input:    `<a href="/home"><img src="logo.png" alt="Logo" height="40"></a>`,
+			opts:     ConvertOptions{KeepImageDimensions: true},
+			expected: "[![Logo](logo.png){height=40}](/home)",
+		},
+		{
+			name:     "invalid dimensions ignored",
+			input:    `<p><img src="hero.jpg" alt="Hero" width="100%" height="auto"></p>`,
+			opts:     ConvertOptions{KeepImageDimensions: true},
+			expected: "![Hero](hero.jpg)",
+		},
+		{
+			name:     "dimensions dropped by default",
+			input:    `<p><img src="logo.png" alt="Logo" width="100" height="50"></p>`,
+			expected: "![Logo](logo.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLargestSrcsetCandidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "width descriptors",
+			input:    "a.jpg 320w, b.jpg 640w",
+			expected: "b.jpg",
+		},
+		{
+			name:     "density descriptors",
+			input:    "a.jpg, b.jpg 1.5x, c.jpg 3x",
+			expected: "c.jpg",
+		},
+		{
+			name:     "single candidate without descriptor",
+			input:    "only.png",
+			expected: "only.png",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := largestSrcsetCandidate(tt.input)
+			if result != tt.expected {
+				t.Errorf("largestSrcsetCandidate() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFixLazyImages(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "data-src replaces placeholder",
+			input:    `<img src="data:image/gif;base64,R0lGOD" data-src="/real.png" alt="A">`,
+			expected: "![A](/real.png)",
+		},
+		{
+			name:     "data-lazy-src fills missing src",
+			input:    `<img data-lazy-src="/lazy.png" alt="B">`,
+			expected: "![B](/lazy.png)",
+		},
+		{
+			name:     "data-srcset used",
+			input:    `<img data-srcset="/small.png 1x, /large.png 2x" alt="C">`,
+			expected: "![C](/large.png)",
+		},
+		{
+			name:     "real src kept",
+			input:    `<img src="/real.png" data-src="/other.png" alt="D">`,
+			expected: "![D](/real.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML(tt.input)
+			if err != nil {
+				t.Fatalf("ParseHTML() failed: %v", err)
+			}
+			fixLazyImages(doc)
+			result := convertDocument(doc, ConvertOptions{})
+			if result != tt.expected {
+				t.Errorf("fixLazyImages() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/textutil"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("detect_language")
+}
+
+// DetectLanguage returns an ISO 639-1 code for the language of an HTML document,
+// or an empty string if it cannot be determined.
+// The <html lang> attribute wins when present; otherwise the language is
+// guessed from the visible text.
+func DetectLanguage(htmlStr string) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	for _, root := range findElements(doc, "html") {
+		if lang := normalizeLanguageTag(getAttr(root, "lang")); lang != "" {
+			return lang
+		}
+	}
+
+	return textutil.GuessLanguage(extractText(doc))
+}
+
+// normalizeLanguageTag reduces a BCP 47 tag such as "fr-CA" to its
+// two-letter primary language subtag, or returns "" if it has none
+func normalizeLanguageTag(tag string) string {
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	primary = strings.ToLower(primary)
+
+	if len(primary) != 2 {
+		return ""
+	}
+	for _, r := range primary {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return primary
+}
@@ -0,0 +1,56 @@
+package html
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "lang attribute",
+			input:    `<html lang="fr"><body><p>Bonjour</p></body></html>`,
+			expected: "fr",
+		},
+		{
+			name:     "lang attribute with region",
+			input:    `<html lang="pt-BR"><body><p>Olá</p></body></html>`,
+			expected: "pt",
+		},
+		{
+			name:     "lang attribute wins over text",
+			input:    `<html lang="DE"><body><p>The text is written in English for the tests.</p></body></html>`,
+			expected: "de",
+		},
+		{
+			name:     "english text without lang",
+			input:    `<html><body><h1>About us</h1><p>We are a small team and this is the story of how the company was founded.</p></body></html>`,
+			expected: "en",
+		},
+		{
+			name:     "invalid lang falls back to text",
+			input:    `<html lang="x-klingon"><body><p>This is the page that you were looking for.</p></body></html>`,
+			expected: "en",
+		},
+		{
+			name:     "script content is ignored",
+			input:    `<html><body><script>var le = la; les = des;</script><p>Hi</p></body></html>`,
+			expected: "",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DetectLanguage(tt.input)
+			if result != tt.expected {
+				t.Errorf("DetectLanguage() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
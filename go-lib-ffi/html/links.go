@@ -0,0 +1,127 @@
+package html
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// defaultLinkScheme completes protocol-relative URLs in documents without
+// an absolute <base href>
+const defaultLinkScheme = "https"
+
+// resolveProtocolRelative gives every protocol-relative href and src below
+// doc, such as "//cdn.example.com/x", the scheme of the document's
+// <base href>, or defaultLinkScheme when it has none
+func resolveProtocolRelative(doc *html.Node) {
+	scheme := documentScheme(doc)
+	for _, el := range findElements(doc, linkAttributeTags...) {
+		for i, attr := range el.Attr {
+			if attr.Key != "href" && attr.Key != "src" {
+				continue
+			}
+			value := strings.TrimSpace(attr.Val)
+			if strings.HasPrefix(value, "//") && len(value) > 2 && value[2] != '/' {
+				el.Attr[i].Val = scheme + ":" + value
+			}
+		}
+	}
+}
+
+// documentScheme returns the http or https scheme of the first absolute
+// <base href> of doc, or defaultLinkScheme
+func documentScheme(doc *html.Node) string {
+	for _, base := range findElements(doc, "base") {
+		u, err := url.Parse(strings.TrimSpace(getAttr(base, "href")))
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if scheme := strings.ToLower(u.Scheme); scheme == "http" || scheme == "https" {
+			return scheme
+		}
+	}
+	return defaultLinkScheme
+}
+
+// linkAttributeTags lists the elements whose href or src attributes
+// hold a link that URL resolution rewrites
+var linkAttributeTags = []string{"a", "area", "img", "source", "link", "script", "iframe", "video", "audio"}
+
+// resolveRelativeLinks makes every relative href and src below doc
+// absolute. Links resolve against the document's <base href>, itself
+// resolved against supplied, or against supplied when the document has none.
+// Fragment-only links such as "#top" stay relative so they keep pointing
+// into the page. Nothing changes when no absolute base can be found.
+func resolveRelativeLinks(doc *html.Node, supplied string) {
+	base, err := url.Parse(strings.TrimSpace(supplied))
+	if err != nil {
+		base = &url.URL{}
+	}
+	for _, el := range findElements(doc, "base") {
+		href := strings.TrimSpace(getAttr(el, "href"))
+		if ref, err := url.Parse(href); err == nil && href != "" {
+			base = base.ResolveReference(ref)
+			break
+		}
+	}
+	if !base.IsAbs() || base.Host == "" {
+		return
+	}
+
+	for _, el := range findElements(doc, linkAttributeTags...) {
+		for i, attr := range el.Attr {
+			if attr.Key != "href" && attr.Key != "src" {
+				continue
+			}
+			value := strings.TrimSpace(attr.Val)
+			if value == "" || strings.HasPrefix(value, "#") {
+				continue
+			}
+			if ref, err := url.Parse(value); err == nil {
+				el.Attr[i].Val = base.ResolveReference(ref).String()
+			}
+		}
+	}
+}
+
+// dropFragmentLinks replaces links pointing into the same page, such as
+// <a href="#section">, with their content
+func dropFragmentLinks(doc *html.Node) {
+	for _, a := range findElements(doc, "a") {
+		if a.Parent == nil || !strings.HasPrefix(strings.TrimSpace(getAttr(a, "href")), "#") {
+			continue
+		}
+		for child := a.FirstChild; child != nil; child = a.FirstChild {
+			a.RemoveChild(child)
+			a.Parent.InsertBefore(child, a)
+		}
+		a.Parent.RemoveChild(a)
+	}
+}
+
+// collapseRepeatedLinks removes links repeating the href and text of the
+// link just before them, with only whitespace in between, so runs such as
+// "[Home](/) [Home](/) [Home](/)" keep a single link
+func collapseRepeatedLinks(doc *html.Node) {
+	for _, a := range findElements(doc, "a") {
+		if a.Parent == nil {
+			continue
+		}
+		href, text := strings.TrimSpace(getAttr(a, "href")), extractText(a)
+		for next := a.NextSibling; next != nil; {
+			following := next.NextSibling
+			switch {
+			case next.Type == html.TextNode && strings.TrimSpace(next.Data) == "":
+			case isElement(next, "a") && strings.TrimSpace(getAttr(next, "href")) == href && extractText(next) == text:
+				// Drop the repeat and the whitespace separating it
+				for sep := a.NextSibling; sep != following; sep = a.NextSibling {
+					a.Parent.RemoveChild(sep)
+				}
+			default:
+				following = nil
+			}
+			next = following
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertProtocolRelativeLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "https by default",
+			input:    `<p><a href="//cdn.example.com/x">cdn</a> <img src="//img.example.com/a.png" alt="a"></p>`,
+			expected: "[cdn](https://cdn.example.com/x) ![a](https://img.example.com/a.png)",
+		},
+		{
+			name:     "scheme from base href",
+			input:    `<html><head><base href="http://example.org/docs/"></head><body><p><a href="//cdn.example.com/x">cdn</a></p></body></html>`,
+			expected: "[cdn](http://cdn.example.com/x)",
+		},
+		{
+			name:     "relative base href ignored",
+			input:    `<html><head><base href="/docs/"></head><body><p><a href=" //cdn.example.com/x">cdn</a></p></body></html>`,
+			expected: "[cdn](https://cdn.example.com/x)",
+		},
+		{
+			name:     "other links untouched",
+			input:    `<p><a href="/about">about</a> <a href="http://example.com/">home</a> <a href="#top">top</a></p>`,
+			expected: "[about](/about) [home](http://example.com/) [top](#top)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDropFragmentLinks(t *testing.T) {
+	input := `<p>See <a href="#usage">the <b>usage</b> section</a> or <a href="/docs#usage">the docs</a>.</p>`
+
+	t.Run("convert", func(t *testing.T) {
+		expected := "See the **usage** section or [the docs](/docs#usage)."
+		result := ConvertHTMLToMarkdownWithOptions(input, ConvertOptions{DropFragmentLinks: true})
+		if result != expected {
+			t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, expected, result)
+		}
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		expected := `<html><head></head><body><p>See the <b>usage</b> section or <a href="/docs#usage">the docs</a>.</p></body></html>`
+		result := CleanHTMLWithOptions(input, CleanOptions{DropFragmentLinks: true})
+		if result != expected {
+			t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+		}
+	})
+
+	t.Run("clean resolves protocol-relative links", func(t *testing.T) {
+		input := `<p><a href="//cdn.example.com/x">cdn</a></p>`
+		expected := `<html><head></head><body><p><a href="https://cdn.example.com/x">cdn</a></p></body></html>`
+		result := CleanHTML(input)
+		if result != expected {
+			t.Errorf("CleanHTML() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+		}
+	})
+}
+
+func TestConvertCollapseRepeatedLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "identical adjacent links collapsed",
+			input:    `<p><a href="/">Home</a> <a href="/">Home</a>  <a href="/">Home</a> Welcome</p>`,
+			opts:     ConvertOptions{CollapseRepeatedLinks: true},
+			expected: "[Home](/) Welcome",
+		},
+		{
+			name:     "different links kept",
+			input:    `<p><a href="/">Home</a> <a href="/about">Home</a> <a href="/about">About</a></p>`,
+			opts:     ConvertOptions{CollapseRepeatedLinks: true},
+			expected: "[Home](/) [Home](/about) [About](/about)",
+		},
+		{
+			name:     "links separated by text kept",
+			input:    `<p><a href="/">Home</a> | <a href="/">Home</a></p>`,
+			opts:     ConvertOptions{CollapseRepeatedLinks: true},
+			expected: "[Home](/) | [Home](/)",
+		},
+		{
+			name:     "runs collapsed separately",
+			input:    `<p><a href="/a">A</a><a href="/a">A</a><a href="/b">B</a><a href="/b">B</a><a href="/a">A</a></p>`,
+			opts:     ConvertOptions{CollapseRepeatedLinks: true},
+			expected: "[A](/a)[B](/b)[A](/a)",
+		},
+		{
+			name:     "kept by default",
+			input:    `<p><a href="/">Home</a> <a href="/">Home</a></p>`,
+			expected: "[Home](/) [Home](/)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLWithBase(t *testing.T) {
+	body := `<p><a href="guide/intro.html">Guide</a> <a href="/about">About</a> <a href="#top">Top</a>` +
+		` <a href="https://other.example/x">Other</a> <img src="img/logo.png"></p>`
+
+	tests := []struct {
+		name     string
+		head     string
+		base     string
+		expected string
+	}{
+		{
+			name:     "supplied base",
+			base:     "https://example.com/docs/index.html",
+			expected: `<p><a href="https://example.com/docs/guide/intro.html">Guide</a> <a href="https://example.com/about">About</a> <a href="#top">Top</a> <a href="https://other.example/x">Other</a> <img src="https://example.com/docs/img/logo.png"/></p>`,
+		},
+		{
+			name:     "document base overrides supplied base",
+			head:     `<base href="https://cdn.example.org/v2/">`,
+			base:     "https://example.com/docs/index.html",
+			expected: `<p><a href="https://cdn.example.org/v2/guide/intro.html">Guide</a> <a href="https://cdn.example.org/about">About</a> <a href="#top">Top</a> <a href="https://other.example/x">Other</a> <img src="https://cdn.example.org/v2/img/logo.png"/></p>`,
+		},
+		{
+			name:     "relative document base resolved against supplied base",
+			head:     `<base href="/v3/">`,
+			base:     "https://example.com/docs/index.html",
+			expected: `<p><a href="https://example.com/v3/guide/intro.html">Guide</a> <a href="https://example.com/about">About</a> <a href="#top">Top</a> <a href="https://other.example/x">Other</a> <img src="https://example.com/v3/img/logo.png"/></p>`,
+		},
+		{
+			name:     "document base without supplied base",
+			head:     `<base href="https://cdn.example.org/v2/">`,
+			base:     "",
+			expected: `<p><a href="guide/intro.html">Guide</a> <a href="/about">About</a> <a href="#top">Top</a> <a href="https://other.example/x">Other</a> <img src="img/logo.png"/></p>`,
+		},
+		{
+			name:     "relative supplied base ignored",
+			base:     "/docs/",
+			expected: `<p><a href="guide/intro.html">Guide</a> <a href="/about">About</a> <a href="#top">Top</a> <a href="https://other.example/x">Other</a> <img src="img/logo.png"/></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := `<html><head>` + tt.head + `</head><body>` + body + `</body></html>`
+			expected := `<html><head>` + strings.Replace(tt.head, ">", "/>", 1) + `</head><body>` + tt.expected + `</body></html>`
+			result := CleanHTMLWithBase(input, tt.base)
+			if result != expected {
+				t.Errorf("CleanHTMLWithBase() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package html
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-lib-ffi/textutil"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxListStart is the largest start number a CommonMark list marker can hold
+// (at most nine digits)
+const maxListStart = 999999999
+
+// normalizeListStarts validates the start attribute of every <ol> so the
+// converter numbers items from it. Values that cannot begin a markdown list,
+// such as negative or non-numeric starts, are removed so numbering begins at 1.
+func normalizeListStarts(doc *html.Node) {
+	for _, list := range findElements(doc, "ol") {
+		if !hasAttr(list, "start") {
+			continue
+		}
+
+		start, err := strconv.Atoi(strings.TrimSpace(getAttr(list, "start")))
+		if err != nil || start < 0 || start > maxListStart {
+			removeAttr(list, "start")
+			continue
+		}
+		setAttr(list, "start", strconv.Itoa(start))
+	}
+}
+
+// truncateLists removes the items after the first maxItems of every list
+// and adds a paragraph counting the removed ones right after the list.
+// A paragraph rather than an item keeps the note out of <ol> numbering.
+func truncateLists(doc *html.Node, maxItems int) {
+	for _, list := range findElements(doc, "ul", "ol") {
+		kept, cut := 0, 0
+		for item := list.FirstChild; item != nil; {
+			next := item.NextSibling
+			if isElement(item, "li") {
+				if kept < maxItems {
+					kept++
+				} else {
+					list.RemoveChild(item)
+					cut++
+				}
+			}
+			item = next
+		}
+
+		if cut > 0 && list.Parent != nil {
+			more := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+			more.AppendChild(&html.Node{Type: html.TextNode, Data: textutil.MoreItems(cut)})
+			list.Parent.InsertBefore(more, list.NextSibling)
+		}
+	}
+}
+
+// romanNumerals pairs roman numeral symbols with their values, largest first
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"}, {100, "c"}, {90, "xc"},
+	{50, "l"}, {40, "xl"}, {10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+}
+
+// renderTypedList writes an <ol> with an alphabetic or roman type attribute
+// as lines starting with literal markers such as "a." or "iv.", since
+// markdown lists are always numbered. Other lists are left to the built-in
+// rules. Continuation lines of an item are indented under its text.
+func renderTypedList(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	listType := strings.TrimSpace(getAttr(n, "type"))
+	switch listType {
+	case "a", "A", "i", "I":
+	default:
+		return converter.RenderTryNext
+	}
+
+	number := 1
+	if start, err := strconv.Atoi(getAttr(n, "start")); err == nil {
+		number = start
+	}
+
+	var items []string
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if !isElement(item, "li") {
+			continue
+		}
+		var buf bytes.Buffer
+		ctx.RenderChildNodes(ctx, &buf, item)
+
+		marker := listItemMarker(listType, number) + ". "
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+		number++
+	}
+
+	w.WriteString("\n\n")
+	w.WriteString(strings.Join(items, "\n"))
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+// listItemMarker returns the marker of item n in a list of the given type:
+// letters for "a" and "A" (z is followed by aa) and roman numerals for "i"
+// and "I". Numbers the type cannot express are written in digits.
+func listItemMarker(listType string, n int) string {
+	var marker string
+	switch {
+	case n < 1:
+		return strconv.Itoa(n)
+	case listType == "a" || listType == "A":
+		for ; n > 0; n = (n - 1) / 26 {
+			marker = string(rune('a'+(n-1)%26)) + marker
+		}
+	case n < 4000:
+		var sb strings.Builder
+		for _, numeral := range romanNumerals {
+			for ; n >= numeral.value; n -= numeral.value {
+				sb.WriteString(numeral.symbol)
+			}
+		}
+		marker = sb.String()
+	default:
+		return strconv.Itoa(n)
+	}
+
+	if listType == "A" || listType == "I" {
+		return strings.ToUpper(marker)
+	}
+	return marker
+}
+
+const (
+	// DefaultNavListLinkRatio is the link text share used by DropNavLists
+	// when ConvertOptions.NavListLinkRatio is 0
+	DefaultNavListLinkRatio = 0.8
+
+	// DefaultNavListMinLinks is the link count used by DropNavLists
+	// when ConvertOptions.NavListMinLinks is 0
+	DefaultNavListMinLinks = 10
+)
+
+// dropNavLists removes the lists below doc holding at least minLinks links
+// whose text is at least linkRatio of the list's text.
+// Zero arguments select the defaults.
+func dropNavLists(doc *html.Node, linkRatio float64, minLinks int) {
+	if linkRatio <= 0 {
+		linkRatio = DefaultNavListLinkRatio
+	}
+	if minLinks <= 0 {
+		minLinks = DefaultNavListMinLinks
+	}
+
+	for _, list := range findElements(doc, "ul", "ol") {
+		if list.Parent == nil {
+			continue
+		}
+
+		links := findElements(list, "a")
+		if len(links) < minLinks {
+			continue
+		}
+
+		linkChars := 0
+		for _, a := range links {
+			linkChars += visibleChars(a)
+		}
+		total := visibleChars(list)
+		if total > 0 && float64(linkChars)/float64(total) >= linkRatio {
+			list.Parent.RemoveChild(list)
+		}
+	}
+}
+
+// visibleChars counts the characters of the text below n, whitespace excluded
+func visibleChars(n *html.Node) int {
+	count := 0
+	for _, r := range extractText(n) {
+		if !unicode.IsSpace(r) {
+			count++
+		}
+	}
+	return count
+}
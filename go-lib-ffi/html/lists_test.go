@@ -0,0 +1,227 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertOrderedListStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "start attribute honored",
+			input:    `<ol start="3"><li>Three</li><li>Four</li><li>Five</li></ol>`,
+			expected: "3. Three\n4. Four\n5. Five",
+		},
+		{
+			name:     "start with surrounding spaces",
+			input:    `<ol start=" 7 "><li>Seven</li></ol>`,
+			expected: "7. Seven",
+		},
+		{
+			name:     "zero start",
+			input:    `<ol start="0"><li>Zero</li><li>One</li></ol>`,
+			expected: "0. Zero\n1. One",
+		},
+		{
+			name:     "negative start falls back to one",
+			input:    `<ol start="-2"><li>First</li><li>Second</li></ol>`,
+			expected: "1. First\n2. Second",
+		},
+		{
+			name:     "non-numeric start falls back to one",
+			input:    `<ol start="c"><li>First</li></ol>`,
+			expected: "1. First",
+		},
+		{
+			name:     "start too large for a list marker",
+			input:    `<ol start="1000000000"><li>First</li></ol>`,
+			expected: "1. First",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertMaxListItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxItems int
+		expected string
+	}{
+		{
+			name:     "list under the limit",
+			input:    `<ul><li>a</li><li>b</li></ul>`,
+			maxItems: 2,
+			expected: "- a\n- b",
+		},
+		{
+			name:     "list over the limit",
+			input:    `<ul><li>a</li><li>b</li><li>c</li><li>d</li></ul>`,
+			maxItems: 2,
+			expected: "- a\n- b\n\n… (+2 more)",
+		},
+		{
+			name:     "ordered list note is not numbered",
+			input:    `<ol><li>a</li><li>b</li><li>c</li></ol>`,
+			maxItems: 2,
+			expected: "1. a\n2. b\n\n… (+1 more)",
+		},
+		{
+			name:     "nested lists limited per level",
+			input:    `<ol><li>a<ul><li>x</li><li>y</li><li>z</li></ul></li><li>b</li><li>c</li></ol>`,
+			maxItems: 1,
+			expected: "1. a\n\n   - x\n\n   … (+2 more)\n\n… (+2 more)",
+		},
+		{
+			name:     "no limit",
+			input:    `<ul><li>a</li><li>b</li><li>c</li></ul>`,
+			expected: "- a\n- b\n- c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{MaxListItems: tt.maxItems})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertPreserveListTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "alphabetic list",
+			input:    `<p>Steps:</p><ol type="a"><li>First</li><li>Second <b>step</b></li></ol><p>End</p>`,
+			opts:     ConvertOptions{PreserveListTypes: true},
+			expected: "Steps:\n\na. First\nb. Second **step**\n\nEnd",
+		},
+		{
+			name:     "roman list",
+			input:    `<ol type="i"><li>one</li><li>two</li><li>three</li><li>four</li></ol>`,
+			opts:     ConvertOptions{PreserveListTypes: true},
+			expected: "i. one\nii. two\niii. three\niv. four",
+		},
+		{
+			name:     "uppercase roman list with start and nested list",
+			input:    `<ol type="I" start="3"><li>Three</li><li>Four<ul><li>x</li><li>y</li></ul></li></ol>`,
+			opts:     ConvertOptions{PreserveListTypes: true},
+			expected: "III. Three\nIV. Four\n\n    - x\n    - y",
+		},
+		{
+			name:     "numeric type left to markdown",
+			input:    `<ol type="1"><li>one</li></ol>`,
+			opts:     ConvertOptions{PreserveListTypes: true},
+			expected: "1. one",
+		},
+		{
+			name:     "type dropped by default",
+			input:    `<ol type="a"><li>First</li><li>Second</li></ol>`,
+			expected: "1. First\n2. Second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestListItemMarker(t *testing.T) {
+	tests := []struct {
+		listType string
+		n        int
+		expected string
+	}{
+		{"a", 1, "a"},
+		{"a", 26, "z"},
+		{"a", 27, "aa"},
+		{"A", 28, "AB"},
+		{"i", 9, "ix"},
+		{"i", 14, "xiv"},
+		{"I", 1994, "MCMXCIV"},
+		{"i", 4000, "4000"},
+		{"a", 0, "0"},
+	}
+
+	for _, tt := range tests {
+		result := listItemMarker(tt.listType, tt.n)
+		if result != tt.expected {
+			t.Errorf("listItemMarker() failed\nInput:    %s, %d\nExpected: %q\nGot:      %q", tt.listType, tt.n, tt.expected, result)
+		}
+	}
+}
+
+func TestConvertDropNavLists(t *testing.T) {
+	navItems := strings.Repeat(`<li><a href="/a">Alpha</a></li><li><a href="/b">Beta</a></li>`, 5)
+	nav := `<ul>` + navItems + `</ul>`
+	prose := `<ul>` + strings.Repeat(`<li>Install the <a href="/cli">command line tool</a> and read the setup guide before you start.</li>`, 10) + `</ul>`
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "navigation list dropped",
+			input:    `<p>Intro</p>` + nav + `<p>Body</p>`,
+			opts:     ConvertOptions{DropNavLists: true},
+			expected: "Intro\n\nBody",
+		},
+		{
+			name:     "prose list kept",
+			input:    prose,
+			opts:     ConvertOptions{DropNavLists: true},
+			expected: strings.TrimSuffix(strings.Repeat("- Install the [command line tool](/cli) and read the setup guide before you start.\n", 10), "\n"),
+		},
+		{
+			name:     "short link list kept",
+			input:    `<ul><li><a href="/a">Alpha</a></li><li><a href="/b">Beta</a></li></ul>`,
+			opts:     ConvertOptions{DropNavLists: true},
+			expected: "- [Alpha](/a)\n- [Beta](/b)",
+		},
+		{
+			name:     "tuned thresholds",
+			input:    `<ul><li><a href="/a">Alpha</a> docs</li><li><a href="/b">Beta</a> docs</li></ul><p>Body</p>`,
+			opts:     ConvertOptions{DropNavLists: true, NavListLinkRatio: 0.5, NavListMinLinks: 2},
+			expected: "Body",
+		},
+		{
+			name:     "kept by default",
+			input:    `<ol><li><a href="/a">Alpha</a></li></ol>`,
+			expected: "1. [Alpha](/a)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
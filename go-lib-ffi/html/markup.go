@@ -0,0 +1,135 @@
+package html
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"golang.org/x/net/html"
+)
+
+// renderDelimited returns a renderer that wraps an element's content in delimiter,
+// e.g. "~~text~~". Surrounding whitespace is moved outside the delimiters
+// and empty elements render nothing.
+func renderDelimited(delimiter string) converter.HandleRenderFunc {
+	return func(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+		var buf bytes.Buffer
+		ctx.RenderChildNodes(ctx, &buf, n)
+		content := buf.String()
+
+		trimmed := strings.TrimSpace(content)
+		if trimmed == "" {
+			w.WriteString(content)
+			return converter.RenderSuccess
+		}
+
+		before, after := surroundingSpace(content)
+		w.WriteString(before)
+		w.WriteString(delimiter)
+		w.WriteString(trimmed)
+		w.WriteString(delimiter)
+		w.WriteString(after)
+
+		return converter.RenderSuccess
+	}
+}
+
+// renderAbbrExpansion renders <abbr title> as its text followed by the
+// title in parentheses. Titles that only repeat the text are omitted.
+func renderAbbrExpansion(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	var buf bytes.Buffer
+	ctx.RenderChildNodes(ctx, &buf, n)
+	content := buf.String()
+
+	title := strings.Join(strings.Fields(getAttr(n, "title")), " ")
+	trimmed := strings.TrimSpace(content)
+	if title == "" || trimmed == "" || strings.EqualFold(title, trimmed) {
+		w.WriteString(content)
+		return converter.RenderSuccess
+	}
+
+	before, after := surroundingSpace(content)
+	w.WriteString(before)
+	w.WriteString(trimmed)
+	w.WriteString(" (")
+	w.Write(ctx.EscapeContent([]byte(title)))
+	w.WriteString(")")
+	w.WriteString(after)
+
+	return converter.RenderSuccess
+}
+
+// renderStyledSpan copies a <span> with a non-empty style attribute as
+// HTML and leaves other spans to the built-in rules
+func renderStyledSpan(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	if strings.TrimSpace(getAttr(n, "style")) == "" {
+		return converter.RenderTryNext
+	}
+	return base.RenderAsHTML(ctx, w, n)
+}
+
+// renderSummary writes the <summary> of a <details> element as a bold line
+// so it reads as the title of the body that follows. Summaries that already
+// contain bold text are left as they are to avoid nested delimiters.
+func renderSummary(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	var buf bytes.Buffer
+	ctx.RenderChildNodes(ctx, &buf, n)
+	content := strings.TrimSpace(buf.String())
+	if content == "" {
+		return converter.RenderSuccess
+	}
+
+	w.WriteString("\n\n")
+	if strings.Contains(content, "**") {
+		w.WriteString(content)
+	} else {
+		w.WriteString("**" + content + "**")
+	}
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+// dropCollapsedDetails removes every <details> element without the open attribute
+func dropCollapsedDetails(doc *html.Node) {
+	for _, details := range findElements(doc, "details") {
+		if details.Parent != nil && !hasAttr(details, "open") {
+			details.Parent.RemoveChild(details)
+		}
+	}
+}
+
+// renderTagHandler adapts a TagHandler to the converter. Output of block
+// elements is separated from the surrounding text by blank lines.
+func renderTagHandler(handler TagHandler) converter.HandleRenderFunc {
+	return func(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+		block := blockElements[n.Data]
+		if block {
+			w.WriteString("\n\n")
+		}
+		w.WriteString(handler(n))
+		if block {
+			w.WriteString("\n\n")
+		}
+		return converter.RenderSuccess
+	}
+}
+
+// TimeDatetimeHandler writes a <time> element as its machine-readable
+// datetime attribute, falling back to its text when the attribute is missing
+func TimeDatetimeHandler(n *html.Node) string {
+	if datetime := strings.TrimSpace(getAttr(n, "datetime")); datetime != "" {
+		return datetime
+	}
+	return extractText(n)
+}
+
+// CiteQuoteHandler writes the text of an element such as <cite> in double quotes
+func CiteQuoteHandler(n *html.Node) string {
+	text := extractText(n)
+	if text == "" {
+		return ""
+	}
+	return "\u201c" + text + "\u201d"
+}
@@ -0,0 +1,302 @@
+package html
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestConvertRevisionMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "del becomes strikethrough",
+			input:    "<p>Price: <del>$20</del> $15</p>",
+			expected: "Price: ~~$20~~ $15",
+		},
+		{
+			name:     "s and strike become strikethrough",
+			input:    "<p><s>old</s> and <strike>older</strike></p>",
+			expected: "~~old~~ and ~~older~~",
+		},
+		{
+			name:     "whitespace moves outside delimiters",
+			input:    "<p>a<del> gone </del>b</p>",
+			expected: "a ~~gone~~ b",
+		},
+		{
+			name:     "ins keeps plain text",
+			input:    "<p>Added <ins>new text</ins> here</p>",
+			expected: "Added new text here",
+		},
+		{
+			name:     "mark is plain by default",
+			input:    "<p>A <mark>key</mark> point</p>",
+			expected: "A key point",
+		},
+		{
+			name:     "mark with highlight delimiter",
+			input:    "<p>A <mark>key</mark> point</p>",
+			opts:     ConvertOptions{MarkDelimiter: "=="},
+			expected: "A ==key== point",
+		},
+		{
+			name:     "mark with custom delimiter",
+			input:    "<p>A <mark>key</mark> point</p>",
+			opts:     ConvertOptions{MarkDelimiter: "**"},
+			expected: "A **key** point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertAbbrExpansion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "abbr with title expanded",
+			input:    `<p>Written in <abbr title="HyperText Markup Language">HTML</abbr>.</p>`,
+			opts:     ConvertOptions{AbbrExpansion: true},
+			expected: "Written in HTML (HyperText Markup Language).",
+		},
+		{
+			name:     "title dropped by default",
+			input:    `<p>Written in <abbr title="HyperText Markup Language">HTML</abbr>.</p>`,
+			expected: "Written in HTML.",
+		},
+		{
+			name:     "abbr without title",
+			input:    `<p>The <abbr>NASA</abbr> mission</p>`,
+			opts:     ConvertOptions{AbbrExpansion: true},
+			expected: "The NASA mission",
+		},
+		{
+			name:     "title repeating the text",
+			input:    `<p><abbr title="css">CSS</abbr> rules</p>`,
+			opts:     ConvertOptions{AbbrExpansion: true},
+			expected: "CSS rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertPreserveRawHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		preserve []string
+		expected string
+	}{
+		{
+			name:     "kbd sub and sup preserved",
+			input:    `<p>Press <kbd>Ctrl</kbd>+<kbd>C</kbd>, drink H<sub>2</sub>O, square x<sup>2</sup>.</p>`,
+			preserve: []string{"kbd", "sub", "sup"},
+			expected: "Press <kbd>Ctrl</kbd>+<kbd>C</kbd>, drink H<sub>2</sub>O, square x<sup>2</sup>.",
+		},
+		{
+			name:     "other tags still convert",
+			input:    `<p><b>Bold</b> and <sup><a href="#fn1">1</a></sup></p>`,
+			preserve: []string{"sup"},
+			expected: `**Bold** and <sup><a href="#fn1">1</a></sup>`,
+		},
+		{
+			name:     "tag names are case-insensitive",
+			input:    `<p>Hit <kbd>Enter</kbd></p>`,
+			preserve: []string{" KBD "},
+			expected: "Hit <kbd>Enter</kbd>",
+		},
+		{
+			name:     "preserved tag overrides built-in rule",
+			input:    `<p>Was <del>old</del> new</p>`,
+			preserve: []string{"del"},
+			expected: "Was <del>old</del> new",
+		},
+		{
+			name:     "block tag kept on its own",
+			input:    `<p>Intro</p><details><summary>More</summary>Hidden</details>`,
+			preserve: []string{"details"},
+			expected: "Intro\n\n<details><summary>More</summary>Hidden</details>",
+		},
+		{
+			name:     "nothing preserved by default",
+			input:    `<p>Press <kbd>Ctrl</kbd> and x<sup>2</sup></p>`,
+			expected: "Press `Ctrl` and x2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{PreserveRawHTML: tt.preserve})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertPreserveStyledSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "styled span kept, plain span unwrapped",
+			input:    `<p>Status: <span style="color:red">failing</span> since <span class="date">Monday</span>.</p>`,
+			opts:     ConvertOptions{PreserveStyledSpans: true},
+			expected: `Status: <span style="color:red">failing</span> since Monday.`,
+		},
+		{
+			name:     "content kept verbatim",
+			input:    `<p><span style="color: green"><b>ok</b> &amp; done</span></p>`,
+			opts:     ConvertOptions{PreserveStyledSpans: true},
+			expected: `<span style="color: green"><b>ok</b> &amp; done</span>`,
+		},
+		{
+			name:     "blank style unwrapped",
+			input:    `<p><span style=" ">plain</span> text</p>`,
+			opts:     ConvertOptions{PreserveStyledSpans: true},
+			expected: "plain text",
+		},
+		{
+			name:     "styled spans unwrapped by default",
+			input:    `<p>Status: <span style="color:red">failing</span></p>`,
+			expected: "Status: failing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertDetails(t *testing.T) {
+	input := `<p>Intro</p><details><summary>More info</summary><p>Hidden body</p><ul><li>Item</li></ul></details>` +
+		`<details open><summary>Shown</summary><p>Visible body</p></details><p>After</p>`
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "summary in bold followed by body",
+			input:    input,
+			expected: "Intro\n\n**More info**\n\nHidden body\n\n- Item\n\n**Shown**\n\nVisible body\n\nAfter",
+		},
+		{
+			name:     "collapsed details dropped",
+			input:    input,
+			opts:     ConvertOptions{DropCollapsedDetails: true},
+			expected: "Intro\n\n**Shown**\n\nVisible body\n\nAfter",
+		},
+		{
+			name:     "summary with bold text not nested",
+			input:    `<details><summary>Read <b>this</b></summary>Body</details>`,
+			expected: "Read **this**\n\nBody",
+		},
+		{
+			name:     "details without summary",
+			input:    `<details><p>Only body</p></details>`,
+			expected: "Only body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertTagHandlers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		handlers map[string]TagHandler
+		expected string
+	}{
+		{
+			name:     "time mapped to its datetime",
+			input:    `<p>Published <time datetime="2024-05-01T10:00:00Z">May 1</time>.</p>`,
+			handlers: map[string]TagHandler{"time": TimeDatetimeHandler},
+			expected: "Published 2024-05-01T10:00:00Z.",
+		},
+		{
+			name:     "time without datetime keeps its text",
+			input:    `<p>Published <time>yesterday</time>.</p>`,
+			handlers: map[string]TagHandler{"time": TimeDatetimeHandler},
+			expected: "Published yesterday.",
+		},
+		{
+			name:     "cite quoted",
+			input:    `<p>As seen in <cite>The Go <i>Blog</i></cite></p>`,
+			handlers: map[string]TagHandler{"cite": CiteQuoteHandler},
+			expected: "As seen in \u201cThe Go Blog\u201d",
+		},
+		{
+			name:  "custom handler",
+			input: `<p>Meet at <time datetime="18:30">half six</time></p>`,
+			handlers: map[string]TagHandler{
+				"TIME": func(n *html.Node) string {
+					return extractText(n) + " (" + getAttr(n, "datetime") + ")"
+				},
+			},
+			expected: "Meet at half six (18:30)",
+		},
+		{
+			name:     "block element handler",
+			input:    `<p>Before</p><blockquote>quoted</blockquote><p>After</p>`,
+			handlers: map[string]TagHandler{"blockquote": func(n *html.Node) string { return "QUOTE: " + extractText(n) }},
+			expected: "Before\n\nQUOTE: quoted\n\nAfter",
+		},
+		{
+			name:     "no handlers",
+			input:    `<p>Published <time datetime="2024-05-01">May 1</time></p>`,
+			expected: "Published May 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{TagHandlers: tt.handlers})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
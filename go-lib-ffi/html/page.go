@@ -0,0 +1,52 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("process_page")
+}
+
+// Page bundles the common representations of a scraped page
+type Page struct {
+	CleanedHTML string `json:"cleaned_html"`
+	Markdown    string `json:"markdown"`
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+}
+
+// ProcessPage returns the cleaned HTML, markdown, title and visible text of
+// a document, each equal to the output of CleanHTML, ConvertHTMLToMarkdown,
+// ExtractTitle and ExtractText. The document is parsed once; cleaning and
+// conversion each work on their own copy of the tree since both modify it.
+func ProcessPage(htmlStr string) Page {
+	if strings.TrimSpace(htmlStr) == "" {
+		return Page{}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return Page{}
+	}
+
+	page := Page{
+		Title: extractTitle(doc),
+		Text:  extractText(doc),
+	}
+
+	page.Markdown = convertDocument(cloneTree(doc), ConvertOptions{})
+
+	cleaned, err := cleanDocument(doc, CleanOptions{})
+	if err != nil {
+		// Match CleanHTML, which returns the input if rendering fails
+		cleaned = htmlStr
+	}
+	page.CleanedHTML = cleaned
+
+	return page
+}
@@ -0,0 +1,61 @@
+package html
+
+import "testing"
+
+func TestProcessPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "empty string",
+			input: "",
+		},
+		{
+			name: "article",
+			input: `<html lang="en"><head><title>Article</title><style>p{}</style></head><body>
+				<nav><a href="/">Home</a></nav>
+				<h1>Heading</h1>
+				<p>Some <b>bold</b> text with a <a href="https://example.com">link</a>.</p>
+				<picture><source srcset="a.webp 1x, b.webp 2x"><img alt="Pic"></picture>
+				<ol start="3"><li>Three</li><li>Four</li></ol>
+				<script>track()</script>
+				<footer>Bye</footer>
+			</body></html>`,
+		},
+		{
+			name:  "fragment without title",
+			input: "<div><h1>Only heading</h1><p>Body</p></div>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := ProcessPage(tt.input)
+
+			if expected := CleanHTML(tt.input); page.CleanedHTML != expected {
+				t.Errorf("ProcessPage() CleanedHTML failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, expected, page.CleanedHTML)
+			}
+			if expected := ConvertHTMLToMarkdown(tt.input); page.Markdown != expected {
+				t.Errorf("ProcessPage() Markdown failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, expected, page.Markdown)
+			}
+			if expected := ExtractTitle(tt.input); page.Title != expected {
+				t.Errorf("ProcessPage() Title failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, expected, page.Title)
+			}
+			if expected := ExtractText(tt.input); page.Text != expected {
+				t.Errorf("ProcessPage() Text failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, expected, page.Text)
+			}
+		})
+	}
+}
+
+func BenchmarkProcessPage(b *testing.B) {
+	input := `<html><head><title>Bench</title></head><body><nav>Menu</nav>` +
+		`<article><h1>Heading</h1><p>Paragraph with <a href="https://example.com">a link</a>.</p>` +
+		`<ul><li>One</li><li>Two</li></ul></article><footer>Bye</footer></body></html>`
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ProcessPage(input)
+	}
+}
@@ -0,0 +1,82 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/textutil"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("preview_clean")
+}
+
+// RemovalRule names the cleaning rule that removes an element
+type RemovalRule string
+
+const (
+	// RemovalRuleTag removes noisy elements such as <script>, <nav> or <footer>
+	RemovalRuleTag RemovalRule = "tag"
+
+	// RemovalRuleAriaHidden removes aria-hidden="true" elements (CleanOptions.DropAriaHidden)
+	RemovalRuleAriaHidden RemovalRule = "aria_hidden"
+
+	// RemovalRuleDuplicate removes repeated blocks (CleanOptions.DedupeBlocks)
+	RemovalRuleDuplicate RemovalRule = "duplicate"
+
+	// RemovalRuleEmptyContainer removes empty containers (CleanOptions.RemoveEmptyContainers)
+	RemovalRuleEmptyContainer RemovalRule = "empty_container"
+)
+
+// previewChars is the length of RemovalDecision.Preview in characters
+const previewChars = 80
+
+// RemovalDecision describes an element CleanHTMLWithOptions would remove
+type RemovalDecision struct {
+	// Tag is the name of the removed element
+	Tag string `json:"tag"`
+
+	// Rule is the rule that removes it
+	Rule RemovalRule `json:"rule"`
+
+	// Preview is the start of the element's text, whitespace collapsed
+	Preview string `json:"preview"`
+}
+
+// removeFunc removes n from the tree on behalf of rule
+type removeFunc func(n *html.Node, rule RemovalRule)
+
+// detachNode removes n from its parent
+func detachNode(n *html.Node, _ RemovalRule) {
+	n.Parent.RemoveChild(n)
+}
+
+// PreviewClean reports the elements CleanHTMLWithOptions would remove from
+// htmlStr with opts, in the order the rules remove them, without producing
+// the cleaned output. Descendants of a removed element are not listed.
+// Inputs rejected by opts.Limits produce no decisions.
+func PreviewClean(htmlStr string, opts CleanOptions) []RemovalDecision {
+	decisions := []RemovalDecision{}
+	if strings.TrimSpace(htmlStr) == "" || opts.Limits.Check(htmlStr) != nil {
+		return decisions
+	}
+
+	doc, err := parseHTML(htmlStr, opts.Fragment)
+	if err != nil {
+		return decisions
+	}
+
+	cleanTreeWith(doc, opts, func(n *html.Node, rule RemovalRule) {
+		preview := strings.Join(strings.Fields(textContent(n)), " ")
+		decisions = append(decisions, RemovalDecision{
+			Tag:     n.Data,
+			Rule:    rule,
+			Preview: textutil.TruncateWords(preview, previewChars),
+		})
+		detachNode(n, rule)
+	})
+
+	return decisions
+}
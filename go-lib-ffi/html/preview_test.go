@@ -0,0 +1,108 @@
+package html
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreviewClean(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     CleanOptions
+		expected []RemovalDecision
+	}{
+		{
+			name:  "noisy tags",
+			input: `<nav><a href="/">Home</a> <a href="/about">About</a></nav><p>Body</p><script>track()</script>`,
+			expected: []RemovalDecision{
+				{Tag: "nav", Rule: RemovalRuleTag, Preview: "Home About"},
+				{Tag: "script", Rule: RemovalRuleTag, Preview: "track()"},
+			},
+		},
+		{
+			name:  "aria-hidden elements",
+			input: `<p><span aria-hidden="true">★</span> Starred</p>`,
+			opts:  CleanOptions{DropAriaHidden: true},
+			expected: []RemovalDecision{
+				{Tag: "span", Rule: RemovalRuleAriaHidden, Preview: "★"},
+			},
+		},
+		{
+			name:  "duplicate blocks",
+			input: `<p>We use cookies.</p><p>Article</p><p>We  use cookies.</p>`,
+			opts:  CleanOptions{DedupeBlocks: true},
+			expected: []RemovalDecision{
+				{Tag: "p", Rule: RemovalRuleDuplicate, Preview: "We use cookies."},
+			},
+		},
+		{
+			name:  "nested duplicates listed once",
+			input: `<ul><li><p>Same text</p></li><li><p>Same text</p></li></ul>`,
+			opts:  CleanOptions{DedupeBlocks: true},
+			expected: []RemovalDecision{
+				{Tag: "li", Rule: RemovalRuleDuplicate, Preview: "Same text"},
+			},
+		},
+		{
+			name:  "containers emptied by other rules",
+			input: `<div class="ad"><script>ads()</script></div><p>Text</p>`,
+			opts:  CleanOptions{RemoveEmptyContainers: true},
+			expected: []RemovalDecision{
+				{Tag: "script", Rule: RemovalRuleTag, Preview: "ads()"},
+				{Tag: "div", Rule: RemovalRuleEmptyContainer, Preview: ""},
+			},
+		},
+		{
+			name:  "word breaks",
+			input: `<p>Ext<wbr>ract</p>`,
+			expected: []RemovalDecision{
+				{Tag: "wbr", Rule: RemovalRuleTag, Preview: ""},
+			},
+		},
+		{
+			name:  "optional rules off by default",
+			input: `<p><span aria-hidden="true">★</span></p><p>A</p><p>A</p><div></div>`,
+		},
+		{
+			name:  "long previews are shortened",
+			input: `<footer>` + strings.Repeat("word ", 40) + `</footer>`,
+			expected: []RemovalDecision{
+				{Tag: "footer", Rule: RemovalRuleTag, Preview: strings.Repeat("word ", 15) + "word…"},
+			},
+		},
+		{
+			name:  "empty input",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PreviewClean(tt.input, tt.opts)
+			expected := tt.expected
+			if expected == nil {
+				expected = []RemovalDecision{}
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("PreviewClean() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", tt.input, expected, result)
+			}
+		})
+	}
+}
+
+func TestPreviewCleanMatchesCleanHTML(t *testing.T) {
+	input := `<div><nav>Menu</nav></div><p>Keep</p><p>Keep</p>`
+	opts := CleanOptions{DedupeBlocks: true, RemoveEmptyContainers: true, Fragment: true}
+
+	decisions := PreviewClean(input, opts)
+	if len(decisions) != 3 {
+		t.Fatalf("PreviewClean() failed\nInput:    %s\nExpected: 3 decisions\nGot:      %+v", input, decisions)
+	}
+
+	expected := `<p>Keep</p>`
+	if result := CleanHTMLWithOptions(input, opts); result != expected {
+		t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", input, expected, result)
+	}
+}
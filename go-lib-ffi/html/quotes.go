@@ -0,0 +1,106 @@
+package html
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// attributionDashes are the characters pages put in front of a quote's source
+const attributionDashes = "—–―-"
+
+// attributeQuotes ends every <blockquote> that names its source with a
+// paragraph "— source". The source is a trailing <footer> or <cite> child,
+// linked to the cite attribute when it holds a web URL, or the URL itself
+// when there is no such child.
+func attributeQuotes(doc *html.Node) {
+	for _, quote := range findElements(doc, "blockquote") {
+		citeURL := quoteCiteURL(quote)
+		source := trailingAttribution(quote)
+		if source == nil && citeURL == "" {
+			continue
+		}
+
+		line := &html.Node{Type: html.ElementNode, Data: "p", DataAtom: atom.P}
+		line.AppendChild(&html.Node{Type: html.TextNode, Data: "— "})
+
+		target := line
+		if citeURL != "" && (source == nil || len(findElements(source, "a")) == 0) {
+			target = &html.Node{Type: html.ElementNode, Data: "a", DataAtom: atom.A,
+				Attr: []html.Attribute{{Key: "href", Val: citeURL}}}
+			line.AppendChild(target)
+		}
+
+		if source != nil {
+			quote.RemoveChild(source)
+			trimLeadingDash(source)
+			for child := source.FirstChild; child != nil; child = source.FirstChild {
+				source.RemoveChild(child)
+				target.AppendChild(child)
+			}
+		}
+		if strings.TrimSpace(textContent(line)) == "—" {
+			if citeURL == "" {
+				continue
+			}
+			target.AppendChild(&html.Node{Type: html.TextNode, Data: citeURL})
+		}
+
+		quote.AppendChild(line)
+	}
+}
+
+// quoteCiteURL returns the cite attribute of quote if it is an absolute
+// http or https URL
+func quoteCiteURL(quote *html.Node) string {
+	cite := strings.TrimSpace(getAttr(quote, "cite"))
+	u, err := url.Parse(cite)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return cite
+}
+
+// trailingAttribution returns the last child of quote if it is a <footer>
+// or <cite> element, ignoring whitespace after it
+func trailingAttribution(quote *html.Node) *html.Node {
+	for child := quote.LastChild; child != nil; child = child.PrevSibling {
+		if child.Type == html.TextNode && strings.TrimSpace(child.Data) == "" {
+			continue
+		}
+		if isElement(child, "footer", "cite") {
+			return child
+		}
+		return nil
+	}
+	return nil
+}
+
+// trimLeadingDash removes the dash and spaces starting the text of n,
+// so "— Author" and "Author" give the same attribution
+func trimLeadingDash(n *html.Node) {
+	var first *html.Node
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil && first == nil; child = child.NextSibling {
+			if child.Type == html.TextNode && strings.TrimSpace(child.Data) != "" {
+				first = child
+				return
+			}
+			walk(child)
+		}
+	}
+	walk(n)
+
+	if first == nil {
+		return
+	}
+
+	// The converter cannot handle empty text nodes, so those are removed
+	first.Data = strings.TrimLeft(first.Data, " \t\n\r"+attributionDashes)
+	if first.Data == "" {
+		first.Parent.RemoveChild(first)
+	}
+}
@@ -0,0 +1,69 @@
+package html
+
+import "testing"
+
+func TestConvertQuoteAttribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		enabled  bool
+		expected string
+	}{
+		{
+			name:     "footer attribution",
+			input:    `<blockquote><p>To be, or not to be.</p><footer>— <cite>William Shakespeare</cite></footer></blockquote>`,
+			enabled:  true,
+			expected: "> To be, or not to be.\n>\n> — William Shakespeare",
+		},
+		{
+			name:     "footer without a dash",
+			input:    `<blockquote><p>Simplicity is prerequisite for reliability.</p><footer>Edsger Dijkstra</footer></blockquote>`,
+			enabled:  true,
+			expected: "> Simplicity is prerequisite for reliability.\n>\n> — Edsger Dijkstra",
+		},
+		{
+			name:     "cite attribute",
+			input:    `<blockquote cite="https://example.com/speech"><p>Ask not.</p></blockquote><p>After</p>`,
+			enabled:  true,
+			expected: "> Ask not.\n>\n> — [https://example.com/speech](https://example.com/speech)\n\nAfter",
+		},
+		{
+			name:     "footer linked to cite attribute",
+			input:    `<blockquote cite="https://example.com/speech"><p>Ask not.</p><footer>- <cite>JFK</cite></footer></blockquote>`,
+			enabled:  true,
+			expected: "> Ask not.\n>\n> — [JFK](https://example.com/speech)",
+		},
+		{
+			name:     "trailing cite element with its own link",
+			input:    `<blockquote cite="https://example.com/a"><p>Quoted.</p><cite><a href="https://example.com/b">Source</a></cite></blockquote>`,
+			enabled:  true,
+			expected: "> Quoted.\n>\n> — [Source](https://example.com/b)",
+		},
+		{
+			name:     "empty footer falls back to cite attribute",
+			input:    `<blockquote cite="https://example.com/speech"><p>Ask not.</p><footer> — </footer></blockquote>`,
+			enabled:  true,
+			expected: "> Ask not.\n>\n> — [https://example.com/speech](https://example.com/speech)",
+		},
+		{
+			name:     "relative cite attribute ignored",
+			input:    `<blockquote cite="/speech"><p>Ask not.</p></blockquote>`,
+			enabled:  true,
+			expected: "> Ask not.",
+		},
+		{
+			name:     "disabled by default",
+			input:    `<blockquote cite="https://example.com/speech"><p>Ask not.</p></blockquote>`,
+			expected: "> Ask not.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, ConvertOptions{QuoteAttribution: tt.enabled})
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
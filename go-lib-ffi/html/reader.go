@@ -0,0 +1,66 @@
+package html
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("reader_mode")
+}
+
+// readerKeepAttributes lists the attributes ReaderMode keeps, the ones the
+// markdown conversion reads
+var readerKeepAttributes = []string{
+	"href", "src", "srcset", "alt", "title", "class", "start", "type",
+	"cite", "datetime", "open", "colspan", "rowspan",
+}
+
+// ReaderMode returns the readable markdown of an article page, combining
+// the cleaning steps most callers want:
+//   - lazy-loaded images get their real src
+//   - noisy and aria-hidden elements, empty containers and unused attributes
+//     are removed, keeping figure captions
+//   - relative links are resolved against baseURL or the page's <base href>
+//   - the main content is selected like ExtractMainContent
+//   - the markdown drops navigation-like link lists and repeated links
+//
+// An empty baseURL leaves relative links as they are.
+func ReaderMode(htmlStr, baseURL string) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	fixLazyImages(doc)
+	cleanTree(doc, CleanOptions{
+		DropAriaHidden:        true,
+		RemoveEmptyContainers: true,
+		KeepFigureCaptions:    true,
+		BaseURL:               baseURL,
+		StripAttributes:       true,
+		KeepAttributes:        readerKeepAttributes,
+	})
+
+	bodies := findElements(doc, "body")
+	if len(bodies) == 0 {
+		return ""
+	}
+	content := findMainContent(bodies[0])
+	if content == nil || utf8.RuneCountInString(extractText(content)) < DefaultMinContentChars {
+		content = bodies[0]
+	}
+
+	return convertDocument(content, ConvertOptions{
+		DropNavLists:          true,
+		CollapseRepeatedLinks: true,
+	})
+}
@@ -0,0 +1,83 @@
+package html
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReaderModeArticle(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/article.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	result := ReaderMode(string(fixture), "https://blog.example.com/posts/go-slices")
+
+	kept := []string{
+		"# Understanding Go Slices",
+		"A slice is a *descriptor* of an array segment.",
+		"[language spec](https://blog.example.com/spec#Slice_types)",
+		"[Ana](https://blog.example.com/authors/ana)",
+		"![Slice header diagram](https://blog.example.com/images/slice-struct.png)",
+		"*The slice header points into the backing array.*",
+		"```go\ns := make([]int, 0, 4)",
+	}
+	for _, want := range kept {
+		if !strings.Contains(result, want) {
+			t.Errorf("ReaderMode() failed\nExpected to contain: %q\nGot:                 %s", want, result)
+		}
+	}
+
+	removed := []string{
+		"cookies",       // aria-hidden banner
+		"Archive",       // site navigation
+		"Popular posts", // sidebar
+		"Tweet",         // aria-hidden share buttons
+		"/posts/arrays", // related links list
+		"Privacy",       // footer
+		"dataLayer",     // scripts
+		"data:image",    // lazy-load placeholder
+		"The Example Blog",
+	}
+	for _, unwanted := range removed {
+		if strings.Contains(result, unwanted) {
+			t.Errorf("ReaderMode() failed\nExpected not to contain: %q\nGot:                     %s", unwanted, result)
+		}
+	}
+}
+
+func TestReaderMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "short page falls back to body",
+			input:    `<nav><a href="/">Home</a></nav><p>Not found. <a href="/search">Search</a></p>`,
+			baseURL:  "https://example.com/missing",
+			expected: "Not found. [Search](https://example.com/search)",
+		},
+		{
+			name:     "relative links kept without base",
+			input:    `<p>See <a href="/docs">the docs</a>.</p>`,
+			expected: "See [the docs](/docs).",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReaderMode(tt.input, tt.baseURL)
+			if result != tt.expected {
+				t.Errorf("ReaderMode() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
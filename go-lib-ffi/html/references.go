@@ -0,0 +1,136 @@
+package html
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// linkReferences collects link URLs rendered out of line during a conversion
+// and assigns each distinct URL a stable number
+type linkReferences struct {
+	urls    []string
+	titles  []string
+	numbers map[string]int
+
+	// keepTitles writes the title attribute of the links in the reference block
+	keepTitles bool
+}
+
+// number returns the reference number for url, assigning the next free
+// number the first time a URL is seen. The first non-empty title given
+// for a URL is the one written in its reference.
+func (r *linkReferences) number(url, title string) int {
+	if r.numbers == nil {
+		r.numbers = make(map[string]int)
+	}
+	if n, ok := r.numbers[url]; ok {
+		if r.titles[n-1] == "" {
+			r.titles[n-1] = title
+		}
+		return n
+	}
+
+	r.urls = append(r.urls, url)
+	r.titles = append(r.titles, title)
+	r.numbers[url] = len(r.urls)
+	return len(r.urls)
+}
+
+// render returns the reference block listing every collected URL,
+// or an empty string if no links were collected
+func (r *linkReferences) render() string {
+	var sb strings.Builder
+	for i, url := range r.urls {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("[")
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString("]: ")
+		sb.WriteString(url)
+		if title := r.titles[i]; title != "" {
+			sb.WriteString(" ")
+			sb.WriteString(quoteLinkTitle(title))
+		}
+	}
+	return sb.String()
+}
+
+// renderFootnoteLink renders <a href> as its text followed by a footnote marker,
+// e.g. "text[1]"
+func (r *linkReferences) renderFootnoteLink(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	return r.renderNumberedLink(ctx, w, n, func(content string, number string) string {
+		return content + "[" + number + "]"
+	})
+}
+
+// renderReferenceLink renders <a href> as a reference-style link, e.g. "[text][1]"
+func (r *linkReferences) renderReferenceLink(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	return r.renderNumberedLink(ctx, w, n, func(content string, number string) string {
+		return "[" + content + "][" + number + "]"
+	})
+}
+
+// renderNumberedLink renders a link whose URL is moved to the reference block.
+// format builds the inline part from the link content and its reference number.
+// Links without href or content fall back to the default link renderer.
+func (r *linkReferences) renderNumberedLink(ctx converter.Context, w converter.Writer, n *html.Node, format func(content, number string) string) converter.RenderStatus {
+	href := strings.TrimSpace(getAttr(n, "href"))
+	href = ctx.AssembleAbsoluteURL(ctx, "a", href)
+	if href == "" {
+		return converter.RenderTryNext
+	}
+
+	content := renderLinkContent(ctx, n)
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return converter.RenderTryNext
+	}
+
+	var title string
+	if r.keepTitles {
+		title = strings.Join(strings.Fields(getAttr(n, "title")), " ")
+	}
+
+	before, after := surroundingSpace(content)
+	w.WriteString(before)
+	w.WriteString(format(trimmed, strconv.Itoa(r.number(href, title))))
+	w.WriteString(after)
+
+	return converter.RenderSuccess
+}
+
+// quoteLinkTitle wraps a link title in double quotes, escaping the
+// backslashes and double quotes inside it
+func quoteLinkTitle(title string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(title) + `"`
+}
+
+// renderLinkContent renders the children of a link as markdown
+func renderLinkContent(ctx converter.Context, n *html.Node) string {
+	ctx = ctx.WithValue("is_inside_link", true)
+
+	var buf bytes.Buffer
+	ctx.RenderChildNodes(ctx, &buf, n)
+	return buf.String()
+}
+
+// surroundingSpace returns a single space for each side of content that
+// starts or ends with whitespace, so it can be kept outside of markup
+func surroundingSpace(content string) (before, after string) {
+	if content == "" {
+		return "", ""
+	}
+	if unicode.IsSpace(rune(content[0])) {
+		before = " "
+	}
+	if unicode.IsSpace(rune(content[len(content)-1])) {
+		after = " "
+	}
+	return before, after
+}
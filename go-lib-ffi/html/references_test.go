@@ -0,0 +1,150 @@
+package html
+
+import "testing"
+
+func TestConvertLinksAsFootnotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "numbering in document order",
+			input:    `<p>See <a href="https://a.example">first</a> and <a href="https://b.example">second</a>.</p>`,
+			expected: "See first[1] and second[2].\n\n[1]: https://a.example\n[2]: https://b.example",
+		},
+		{
+			name:     "identical URLs share a number",
+			input:    `<p><a href="https://a.example">One</a>, <a href="https://b.example">two</a>, <a href="https://a.example">again</a></p>`,
+			expected: "One[1], two[2], again[1]\n\n[1]: https://a.example\n[2]: https://b.example",
+		},
+		{
+			name:     "formatting inside link text",
+			input:    `<p>Read <a href="/docs"><strong>the docs</strong></a> now</p>`,
+			expected: "Read **the docs**[1] now\n\n[1]: /docs",
+		},
+		{
+			name:     "no links produces no reference block",
+			input:    `<p>Just text</p>`,
+			expected: "Just text",
+		},
+		{
+			name:     "link without href stays plain",
+			input:    `<p><a>anchor</a> text</p>`,
+			expected: "[anchor]() text",
+		},
+	}
+
+	opts := ConvertOptions{LinksAsFootnotes: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertHTMLToMarkdownWithOptionsDefaults(t *testing.T) {
+	input := `<p>See <a href="https://a.example">first</a></p>`
+	if ConvertHTMLToMarkdownWithOptions(input, ConvertOptions{}) != ConvertHTMLToMarkdown(input) {
+		t.Errorf("zero ConvertOptions should match ConvertHTMLToMarkdown")
+	}
+}
+
+func TestConvertReferencedLinkStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "reference block generation",
+			input:    `<p>Read <a href="https://a.example">this</a> and <a href="https://b.example">that</a>.</p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced},
+			expected: "Read [this][1] and [that][2].\n\n[1]: https://a.example\n[2]: https://b.example",
+		},
+		{
+			name:     "repeated URLs share a reference",
+			input:    `<ul><li><a href="/home">Home</a></li><li><a href="/docs">Docs</a></li><li><a href="/home">Start</a></li></ul>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced},
+			expected: "- [Home][1]\n- [Docs][2]\n- [Start][1]\n\n[1]: /home\n[2]: /docs",
+		},
+		{
+			name:     "inline style is the default",
+			input:    `<p><a href="https://a.example">this</a></p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleInline},
+			expected: "[this](https://a.example)",
+		},
+		{
+			name:     "footnotes take precedence",
+			input:    `<p><a href="https://a.example">this</a></p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced, LinksAsFootnotes: true},
+			expected: "this[1]\n\n[1]: https://a.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertLinkTitles(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     ConvertOptions
+		expected string
+	}{
+		{
+			name:     "inline link with title",
+			input:    `<p><a href="https://a.example" title="The A site">A</a></p>`,
+			expected: `[A](https://a.example "The A site")`,
+		},
+		{
+			name:     "inline link without title",
+			input:    `<p><a href="https://a.example">A</a></p>`,
+			expected: `[A](https://a.example)`,
+		},
+		{
+			name:     "reference with title",
+			input:    `<p><a href="https://a.example" title="The A site">A</a></p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced, LinkTitles: true},
+			expected: "[A][1]\n\n[1]: https://a.example \"The A site\"",
+		},
+		{
+			name:     "quotes and backslashes escaped",
+			input:    `<p><a href="/q" title="Say &quot;hi&quot; \ wave">Q</a></p>`,
+			opts:     ConvertOptions{LinksAsFootnotes: true, LinkTitles: true},
+			expected: "Q[1]\n\n[1]: /q \"Say \\\"hi\\\" \\\\ wave\"",
+		},
+		{
+			name:     "title taken from a later link to the same URL",
+			input:    `<p><a href="https://a.example">A</a> <a href="https://a.example" title="  Later&#10; title ">again</a></p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced, LinkTitles: true},
+			expected: "[A][1] [again][1]\n\n[1]: https://a.example \"Later title\"",
+		},
+		{
+			name:     "reference titles dropped by default",
+			input:    `<p><a href="https://a.example" title="The A site">A</a></p>`,
+			opts:     ConvertOptions{LinkStyle: LinkStyleReferenced},
+			expected: "[A][1]\n\n[1]: https://a.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertHTMLToMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ConvertHTMLToMarkdownWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
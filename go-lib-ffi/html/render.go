@@ -0,0 +1,68 @@
+package html
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// VoidElementStyle selects how void elements are written in rendered HTML
+type VoidElementStyle string
+
+const (
+	// VoidElementsXHTML self-closes void elements, e.g. <br/> (the renderer
+	// default, also used for empty and unknown values)
+	VoidElementsXHTML VoidElementStyle = "xhtml"
+
+	// VoidElementsHTML writes void elements without a closing slash, e.g. <br>
+	VoidElementsHTML VoidElementStyle = "html"
+)
+
+// voidElements lists the HTML elements that never have content or an end tag
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// unselfCloseVoidElements writes void tags in rendered HTML without their
+// closing slash (VoidElementsHTML). x/net/html.Render always self-closes
+// void elements, so the output is re-tokenized and every other token is
+// copied through unchanged.
+func unselfCloseVoidElements(rendered string) string {
+	var sb strings.Builder
+	sb.Grow(len(rendered))
+
+	z := html.NewTokenizer(strings.NewReader(rendered))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		raw := z.Raw()
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			name, _ := z.TagName()
+			if voidElements[string(name)] && bytes.HasSuffix(raw, []byte(">")) {
+				tag := bytes.TrimRight(bytes.TrimSuffix(bytes.TrimSuffix(raw, []byte(">")), []byte("/")), " ")
+				sb.Write(tag)
+				sb.WriteString(">")
+				continue
+			}
+		}
+		sb.Write(raw)
+	}
+
+	return sb.String()
+}
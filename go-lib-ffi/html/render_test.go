@@ -0,0 +1,62 @@
+package html
+
+import "testing"
+
+func TestCleanHTMLVoidElementStyle(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		xhtmlStyle string
+		htmlStyle  string
+	}{
+		{
+			name:       "br",
+			input:      "<p>Line one<br>Line two</p>",
+			xhtmlStyle: "<html><head></head><body><p>Line one<br/>Line two</p></body></html>",
+			htmlStyle:  "<html><head></head><body><p>Line one<br>Line two</p></body></html>",
+		},
+		{
+			name:       "img with attributes",
+			input:      `<img src="a.png" alt="x/y">`,
+			xhtmlStyle: `<html><head></head><body><img src="a.png" alt="x/y"/></body></html>`,
+			htmlStyle:  `<html><head></head><body><img src="a.png" alt="x/y"></body></html>`,
+		},
+		{
+			name:       "hr",
+			input:      "<p>Before</p><hr><p>After</p>",
+			xhtmlStyle: "<html><head></head><body><p>Before</p><hr/><p>After</p></body></html>",
+			htmlStyle:  "<html><head></head><body><p>Before</p><hr><p>After</p></body></html>",
+		},
+		{
+			name:       "escaped tag text is untouched",
+			input:      "<p>Use &lt;br/&gt; for breaks</p>",
+			xhtmlStyle: "<html><head></head><body><p>Use &lt;br/&gt; for breaks</p></body></html>",
+			htmlStyle:  "<html><head></head><body><p>Use &lt;br/&gt; for breaks</p></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, style := range []VoidElementStyle{"", VoidElementsXHTML, "bogus"} {
+				result := CleanHTMLWithOptions(tt.input, CleanOptions{VoidElementStyle: style})
+				if result != tt.xhtmlStyle {
+					t.Errorf("CleanHTMLWithOptions(%q) failed\nInput:    %s\nExpected: %s\nGot:      %s", style, tt.input, tt.xhtmlStyle, result)
+				}
+			}
+
+			result := CleanHTMLWithOptions(tt.input, CleanOptions{VoidElementStyle: VoidElementsHTML})
+			if result != tt.htmlStyle {
+				t.Errorf("CleanHTMLWithOptions(html) failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.htmlStyle, result)
+			}
+		})
+	}
+}
+
+func TestUnselfCloseVoidElements(t *testing.T) {
+	input := `<p>a<br>b<br/>c<br />d</p><img src="x">`
+	expected := `<p>a<br>b<br>c<br>d</p><img src="x">`
+
+	if result := unselfCloseVoidElements(input); result != expected {
+		t.Errorf("unselfCloseVoidElements() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+	}
+}
@@ -0,0 +1,86 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("split_sections")
+}
+
+// headingTags lists the elements that start a new section
+var headingTags = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+
+// Section is a run of HTML introduced by a heading
+type Section struct {
+	// Heading is the text of the heading, empty for the preamble
+	Heading string `json:"heading"`
+
+	// Level is the heading level from 1 to 6, or 0 for the preamble
+	Level int `json:"level"`
+
+	// HTML is the content between this heading and the next one,
+	// without the heading element itself
+	HTML string `json:"html"`
+}
+
+// SplitHTMLByHeadings splits the body of an HTML document into sections at
+// every <h1>-<h6>. Content before the first heading becomes a preamble section
+// with an empty heading; it is omitted when blank.
+// Wrapper elements that contain headings, such as <article>, are split
+// through and not repeated in the sections.
+func SplitHTMLByHeadings(htmlStr string) []Section {
+	sections := []Section{}
+	if strings.TrimSpace(htmlStr) == "" {
+		return sections
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return sections
+	}
+
+	var current *Section
+	var content strings.Builder
+	flush := func() {
+		body := strings.TrimSpace(content.String())
+		content.Reset()
+		if current == nil {
+			if body == "" {
+				return
+			}
+			current = &Section{}
+		}
+		current.HTML = body
+		sections = append(sections, *current)
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			switch {
+			case isElement(child, headingTags...):
+				flush()
+				current = &Section{
+					Heading: extractText(child),
+					Level:   int(child.Data[1] - '0'),
+				}
+			case len(findElements(child, headingTags...)) > 0:
+				walk(child)
+			default:
+				_ = html.Render(&content, child)
+			}
+		}
+	}
+
+	for _, body := range findElements(doc, "body") {
+		walk(body)
+	}
+	flush()
+
+	return sections
+}
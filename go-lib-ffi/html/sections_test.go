@@ -0,0 +1,70 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitHTMLByHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Section
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: []Section{},
+		},
+		{
+			name:  "no headings",
+			input: "<p>Just text</p>",
+			expected: []Section{
+				{HTML: "<p>Just text</p>"},
+			},
+		},
+		{
+			name: "multi-section article with preamble",
+			input: `<html><body>
+				<p>Intro paragraph</p>
+				<h1>Title</h1>
+				<p>Lead</p>
+				<h2>First <em>part</em></h2>
+				<p>One</p><ul><li>a</li></ul>
+				<h2>Second part</h2>
+				<p>Two</p>
+			</body></html>`,
+			expected: []Section{
+				{HTML: "<p>Intro paragraph</p>"},
+				{Heading: "Title", Level: 1, HTML: "<p>Lead</p>"},
+				{Heading: "First part", Level: 2, HTML: "<p>One</p><ul><li>a</li></ul>"},
+				{Heading: "Second part", Level: 2, HTML: "<p>Two</p>"},
+			},
+		},
+		{
+			name:  "headings nested in wrappers",
+			input: `<article><header><h1>Title</h1></header><section><p>Body</p><h3>Notes</h3><p>End</p></section></article>`,
+			expected: []Section{
+				{Heading: "Title", Level: 1, HTML: "<p>Body</p>"},
+				{Heading: "Notes", Level: 3, HTML: "<p>End</p>"},
+			},
+		},
+		{
+			name:  "blank preamble omitted and empty sections kept",
+			input: "  <h2>A</h2><h2>B</h2><p>b</p>",
+			expected: []Section{
+				{Heading: "A", Level: 2, HTML: ""},
+				{Heading: "B", Level: 2, HTML: "<p>b</p>"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SplitHTMLByHeadings(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SplitHTMLByHeadings() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
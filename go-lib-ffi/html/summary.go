@@ -0,0 +1,149 @@
+package html
+
+import (
+	"strings"
+	"time"
+
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/textutil"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("extract_summary")
+}
+
+// Summary is a compact preview of a page
+type Summary struct {
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	FirstParagraph string `json:"first_paragraph"`
+	Image          string `json:"image"`
+	Published      string `json:"published"`
+}
+
+// publishedLayouts pairs the date formats accepted for Summary.Published
+// with the ISO 8601 layout each is normalized to
+var publishedLayouts = []struct {
+	parse, format string
+}{
+	{time.RFC3339Nano, time.RFC3339},
+	{"2006-01-02T15:04:05Z0700", time.RFC3339},
+	{"2006-01-02T15:04Z07:00", time.RFC3339},
+	{"2006-01-02 15:04:05Z07:00", time.RFC3339},
+	{"2006-01-02T15:04:05", "2006-01-02T15:04:05"},
+	{"2006-01-02T15:04", "2006-01-02T15:04:05"},
+	{"2006-01-02 15:04:05", "2006-01-02T15:04:05"},
+	{"2006-01-02 15:04", "2006-01-02T15:04:05"},
+	{"2006-01-02", "2006-01-02"},
+}
+
+// ExtractSummary returns a preview of a document drawn from its metadata,
+// falling back to the cleaned body:
+//   - Title from og:title, else like ExtractTitle
+//   - Description from the description, og:description or twitter:description meta tag
+//   - FirstParagraph as the text of the first non-empty <p> of the main content
+//   - Image from og:image or twitter:image, else the first <img> of the main content
+//   - Published from article:published_time, else the first <time datetime>,
+//     normalized to ISO 8601 ("2024-01-05" or "2024-01-05T10:00:00Z");
+//     dates in other formats are skipped
+//
+// Text fields longer than maxChars are shortened on a word boundary;
+// a maxChars of 0 or less keeps them whole. URLs are returned as written.
+func ExtractSummary(htmlStr string, maxChars int) Summary {
+	if strings.TrimSpace(htmlStr) == "" {
+		return Summary{}
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return Summary{}
+	}
+
+	summary := Summary{
+		Title:       metaContent(doc, "og:title"),
+		Description: metaContent(doc, "description", "og:description", "twitter:description"),
+		Image:       metaContent(doc, "og:image", "twitter:image"),
+	}
+	if summary.Title == "" {
+		summary.Title = extractTitle(doc)
+	}
+	summary.Published = publishedDate(doc)
+
+	// Metadata lives in <head>, so the body is cleaned only after reading it
+	cleanTree(doc, CleanOptions{})
+	if bodies := findElements(doc, "body"); len(bodies) > 0 {
+		content := findMainContent(bodies[0])
+		if content == nil {
+			content = bodies[0]
+		}
+
+		for _, p := range findElements(content, "p") {
+			if text := extractText(p); text != "" {
+				summary.FirstParagraph = text
+				break
+			}
+		}
+
+		if summary.Image == "" {
+			for _, img := range findElements(content, "img") {
+				if src := strings.TrimSpace(getAttr(img, "src")); src != "" {
+					summary.Image = src
+					break
+				}
+			}
+		}
+	}
+
+	summary.Title = textutil.TruncateWords(summary.Title, maxChars)
+	summary.Description = textutil.TruncateWords(summary.Description, maxChars)
+	summary.FirstParagraph = textutil.TruncateWords(summary.FirstParagraph, maxChars)
+
+	return summary
+}
+
+// metaContent returns the content of the first <meta> whose name or property
+// is one of keys, trying keys in order. Whitespace is collapsed.
+func metaContent(doc *html.Node, keys ...string) string {
+	metas := findElements(doc, "meta")
+	for _, key := range keys {
+		for _, meta := range metas {
+			if !strings.EqualFold(getAttr(meta, "name"), key) && !strings.EqualFold(getAttr(meta, "property"), key) {
+				continue
+			}
+			if content := strings.Join(strings.Fields(getAttr(meta, "content")), " "); content != "" {
+				return content
+			}
+		}
+	}
+	return ""
+}
+
+// publishedDate returns the normalized publication date of doc from its
+// article:published_time meta tag or, failing that, its first <time> element
+// with a valid datetime attribute. <time> elements are read before cleaning
+// since they often sit in an article <header>.
+func publishedDate(doc *html.Node) string {
+	if published := normalizeDate(metaContent(doc, "article:published_time")); published != "" {
+		return published
+	}
+	for _, t := range findElements(doc, "time") {
+		if published := normalizeDate(getAttr(t, "datetime")); published != "" {
+			return published
+		}
+	}
+	return ""
+}
+
+// normalizeDate rewrites a date or date-time in one of publishedLayouts
+// as ISO 8601, or returns "" when value matches none of them
+func normalizeDate(value string) string {
+	value = strings.TrimSpace(value)
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout.parse, value); err == nil {
+			return t.Format(layout.format)
+		}
+	}
+	return ""
+}
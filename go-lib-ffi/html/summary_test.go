@@ -0,0 +1,112 @@
+package html
+
+import "testing"
+
+func TestExtractSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxChars int
+		expected Summary
+	}{
+		{
+			name: "full metadata",
+			input: `<html><head><title>Go 1.22 Released - The Go Blog</title>
+				<meta name="description" content="Go 1.22 brings  range-over-int and better loops.">
+				<meta property="og:title" content="Go 1.22 is released">
+				<meta property="og:image" content="https://go.dev/images/go122.png">
+				</head><body><nav><p>Home | Blog</p></nav>
+				<article><p>Today the Go team is happy to release Go 1.22.</p><p>More text.</p>
+				<img src="/inline.png"></article></body></html>`,
+			expected: Summary{
+				Title:          "Go 1.22 is released",
+				Description:    "Go 1.22 brings range-over-int and better loops.",
+				FirstParagraph: "Today the Go team is happy to release Go 1.22.",
+				Image:          "https://go.dev/images/go122.png",
+			},
+		},
+		{
+			name: "body fallback",
+			input: `<html><head></head><body><header><img src="/logo.png"></header>
+				<h1>Weekend  recipes</h1><div class="post"><p> </p><p>Slow-cooked beans with garlic.</p>
+				<img src="/beans.jpg" alt="Beans"></div><footer><p>© 2024</p></footer></body></html>`,
+			expected: Summary{
+				Title:          "Weekend recipes",
+				FirstParagraph: "Slow-cooked beans with garlic.",
+				Image:          "/beans.jpg",
+			},
+		},
+		{
+			name: "twitter tags and truncation",
+			input: `<html><head><title>A very long page title about many things</title>
+				<meta name="twitter:description" content="A description that goes on and on">
+				<meta name="twitter:image" content="card.png"></head>
+				<body><p>First paragraph of the page body.</p></body></html>`,
+			maxChars: 20,
+			expected: Summary{
+				Title:          "A very long page…",
+				Description:    "A description that…",
+				FirstParagraph: "First paragraph of…",
+				Image:          "card.png",
+			},
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: Summary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractSummary(tt.input, tt.maxChars)
+			if result != tt.expected {
+				t.Errorf("ExtractSummary() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractSummaryPublished(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "time element date",
+			input:    `<article><header><time datetime="2024-01-05">Jan 5</time></header><p>Text</p></article>`,
+			expected: "2024-01-05",
+		},
+		{
+			name:     "time element with offset",
+			input:    `<p>Posted <time datetime=" 2024-01-05T10:30:00+0100 ">this morning</time></p>`,
+			expected: "2024-01-05T10:30:00+01:00",
+		},
+		{
+			name:     "invalid time skipped",
+			input:    `<p><time datetime="PT2H">2h</time> ago, <time datetime="2024-01-05 08:00">Friday</time></p>`,
+			expected: "2024-01-05T08:00:00",
+		},
+		{
+			name: "meta tag preferred",
+			input: `<html><head><meta property="article:published_time" content="2023-12-31T23:59:59.5Z"></head>` +
+				`<body><time datetime="2024-01-05">Updated Jan 5</time></body></html>`,
+			expected: "2023-12-31T23:59:59Z",
+		},
+		{
+			name:     "no date",
+			input:    `<p><time>yesterday</time></p>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractSummary(tt.input, 0).Published
+			if result != tt.expected {
+				t.Errorf("ExtractSummary() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package html
+
+import (
+	"strconv"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("extract_tables")
+}
+
+// maxColspan caps colspan values like browsers do, so a hostile attribute
+// cannot allocate huge rows
+const maxColspan = 1000
+
+// ExtractTables returns the text of every <table> in a document as rows of
+// cells, header rows included, in document order. A cell spanning several
+// columns or rows with colspan or rowspan is repeated in every position it
+// covers. Nested tables are extracted separately and their text is also kept
+// in the cell containing them.
+func ExtractTables(htmlStr string) [][][]string {
+	tables := [][][]string{}
+	if strings.TrimSpace(htmlStr) == "" {
+		return tables
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return tables
+	}
+
+	for _, table := range findElements(doc, "table") {
+		tables = append(tables, extractTable(table))
+	}
+	return tables
+}
+
+// extractTable lays out the cells of table on a grid, resolving spans
+func extractTable(table *html.Node) [][]string {
+	rows := tableRows(table)
+	grid := make([][]string, len(rows))
+	filled := make([][]bool, len(rows))
+
+	for r, row := range rows {
+		// A row without cells stays an empty row rather than a nil one,
+		// which would encode as null
+		if grid[r] == nil {
+			grid[r] = []string{}
+		}
+
+		col := 0
+		for cell := row.FirstChild; cell != nil; cell = cell.NextSibling {
+			if !isElement(cell, "td", "th") {
+				continue
+			}
+
+			// Skip positions taken by rowspans from the rows above
+			for col < len(filled[r]) && filled[r][col] {
+				col++
+			}
+
+			text := extractText(cell)
+			colspan := min(spanAttr(cell, "colspan"), maxColspan)
+			rowspan := min(spanAttr(cell, "rowspan"), len(rows)-r)
+			for dr := 0; dr < rowspan; dr++ {
+				for dc := 0; dc < colspan; dc++ {
+					grid[r+dr], filled[r+dr] = setCell(grid[r+dr], filled[r+dr], col+dc, text)
+				}
+			}
+			col += colspan
+		}
+	}
+
+	return grid
+}
+
+// setCell stores text at index col of row, growing row and its filled mask
+func setCell(row []string, filled []bool, col int, text string) ([]string, []bool) {
+	for len(row) <= col {
+		row = append(row, "")
+		filled = append(filled, false)
+	}
+	row[col] = text
+	filled[col] = true
+	return row, filled
+}
+
+// tableRows returns the <tr> elements belonging to table in document order,
+// leaving out the rows of nested tables
+func tableRows(table *html.Node) []*html.Node {
+	var rows []*html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			switch {
+			case isElement(child, "tr"):
+				rows = append(rows, child)
+			case isElement(child, "thead", "tbody", "tfoot"):
+				walk(child)
+			}
+		}
+	}
+	walk(table)
+	return rows
+}
+
+// spanAttr returns the colspan or rowspan of a cell, 1 when missing or invalid
+func spanAttr(cell *html.Node, key string) int {
+	span, err := strconv.Atoi(strings.TrimSpace(getAttr(cell, key)))
+	if err != nil || span < 1 {
+		return 1
+	}
+	return span
+}
@@ -0,0 +1,94 @@
+package html
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTables(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [][][]string
+	}{
+		{
+			name: "simple table",
+			input: `<table>
+				<thead><tr><th>Name</th><th>Age</th></tr></thead>
+				<tbody><tr><td>Alice</td><td>30</td></tr><tr><td>Bob</td><td> 25 </td></tr></tbody>
+			</table>`,
+			expected: [][][]string{{
+				{"Name", "Age"},
+				{"Alice", "30"},
+				{"Bob", "25"},
+			}},
+		},
+		{
+			name: "colspan and rowspan",
+			input: `<table>
+				<tr><th colspan="2">Person</th><th>City</th></tr>
+				<tr><td rowspan="2">Smith</td><td>Alice</td><td rowspan="2">Paris</td></tr>
+				<tr><td>Bob</td></tr>
+				<tr><td>Jones</td><td colspan="2">unknown</td></tr>
+			</table>`,
+			expected: [][][]string{{
+				{"Person", "Person", "City"},
+				{"Smith", "Alice", "Paris"},
+				{"Smith", "Bob", "Paris"},
+				{"Jones", "unknown", "unknown"},
+			}},
+		},
+		{
+			name:  "rowspan past the last row is clamped",
+			input: `<table><tr><td rowspan="5">A</td><td>B</td></tr><tr><td>C</td></tr></table>`,
+			expected: [][][]string{{
+				{"A", "B"},
+				{"A", "C"},
+			}},
+		},
+		{
+			name:  "invalid spans count as one",
+			input: `<table><tr><td colspan="x">A</td><td colspan="0">B</td><td rowspan="-1">C</td></tr></table>`,
+			expected: [][][]string{{
+				{"A", "B", "C"},
+			}},
+		},
+		{
+			name:  "multiple and nested tables",
+			input: `<table><tr><td>Outer</td><td><table><tr><td>Inner</td></tr></table></td></tr></table><table><tr><td>Second</td></tr></table>`,
+			expected: [][][]string{
+				{{"Outer", "Inner"}},
+				{{"Inner"}},
+				{{"Second"}},
+			},
+		},
+		{
+			name:  "row without cells",
+			input: `<table><tr><td>A</td></tr><tr></tr><tr><td>B</td></tr></table>`,
+			expected: [][][]string{{
+				{"A"},
+				{},
+				{"B"},
+			}},
+		},
+		{
+			name:     "no tables",
+			input:    `<p>No tables here</p>`,
+			expected: [][][]string{},
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: [][][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTables(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExtractTables() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package html
+
+import (
+	"strings"
+
+	"go-lib-ffi/textutil"
+
+	"golang.org/x/net/html"
+)
+
+// TextOptions controls optional behavior of ExtractTextWithOptions.
+// The zero value produces the same output as ExtractText.
+type TextOptions struct {
+	// PreserveBlocks keeps a blank line between block elements such as
+	// paragraphs, list items and headings, and a line break for <br>.
+	// Whitespace inside each block is still collapsed to single spaces.
+	PreserveBlocks bool `json:"preserve_blocks"`
+
+	// DropAriaHidden skips elements marked aria-hidden="true", like
+	// CleanOptions.DropAriaHidden
+	DropAriaHidden bool `json:"drop_aria_hidden"`
+
+	// FormValues includes the default values of form controls, like
+	// CleanOptions.FormValues
+	FormValues bool `json:"form_values"`
+
+	// StripInvisible removes zero-width characters, bidirectional marks and
+	// control characters from the text (see textutil.StripInvisible) so
+	// they cannot split words or inflate token counts
+	StripInvisible bool `json:"strip_invisible"`
+}
+
+// Sentinels marking block and line boundaries while text is collected.
+// They are private-use runes, which do not occur in visible text.
+const (
+	blockBreak = '\uE000'
+	lineBreak  = '\uE001'
+)
+
+// ExtractText returns the visible text of an HTML document.
+// Scripts, styles and other invisible elements are skipped, block elements
+// are separated by whitespace and all whitespace is collapsed to single spaces.
+// Words split by <wbr> or soft hyphens are joined back together.
+func ExtractText(htmlStr string) string {
+	return ExtractTextWithOptions(htmlStr, TextOptions{})
+}
+
+// ExtractTextWithOptions returns the visible text of an HTML document like
+// ExtractText with the behaviors selected in opts
+func ExtractTextWithOptions(htmlStr string, opts TextOptions) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	if opts.DropAriaHidden {
+		removeAriaHidden(doc, detachNode)
+	}
+
+	if opts.FormValues {
+		inlineFormValues(doc)
+	}
+
+	if opts.StripInvisible {
+		stripInvisibleText(doc)
+	}
+
+	if opts.PreserveBlocks {
+		return extractTextBlocks(doc)
+	}
+	return extractText(doc)
+}
+
+// ExtractTextFromNode returns the visible text below node like ExtractText,
+// for trees already parsed with ParseHTML. A nil node has no text.
+func ExtractTextFromNode(node *html.Node) string {
+	if node == nil {
+		return ""
+	}
+	return extractText(node)
+}
+
+// stripInvisibleText removes invisible characters from every text node
+// below node. Emptied nodes are left in place since they hold no text.
+func stripInvisibleText(node *html.Node) {
+	if node.Type == html.TextNode {
+		node.Data = textutil.StripInvisible(node.Data)
+		return
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		stripInvisibleText(child)
+	}
+}
+
+// extractText collects the visible text below node
+func extractText(node *html.Node) string {
+	var sb strings.Builder
+	writeVisibleText(&sb, node)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// writeVisibleText writes the visible text below node to sb, with spaces
+// around block elements and whitespace left uncollapsed
+func writeVisibleText(sb *strings.Builder, node *html.Node) {
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			sb.WriteString(strings.ReplaceAll(n.Data, softHyphen, ""))
+			return
+		case html.ElementNode:
+			if invisibleElements[n.Data] {
+				return
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.Data]
+		if block {
+			sb.WriteString(" ")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			sb.WriteString(" ")
+		}
+	}
+	walk(node)
+}
+
+// extractTextBlocks collects the visible text below node with a blank line
+// between blocks and a line break for every <br>
+func extractTextBlocks(node *html.Node) string {
+	var sb strings.Builder
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			// Drop stray sentinels so they cannot create breaks
+			sb.WriteString(strings.Map(func(r rune) rune {
+				switch r {
+				case blockBreak, lineBreak:
+					return ' '
+				}
+				return r
+			}, strings.ReplaceAll(n.Data, softHyphen, "")))
+			return
+		case html.ElementNode:
+			if invisibleElements[n.Data] {
+				return
+			}
+			if n.Data == "br" {
+				sb.WriteRune(lineBreak)
+				return
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.Data]
+		if block {
+			sb.WriteRune(blockBreak)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			sb.WriteRune(blockBreak)
+		}
+	}
+	walk(node)
+
+	var blocks []string
+	for _, block := range strings.Split(sb.String(), string(blockBreak)) {
+		var lines []string
+		for _, line := range strings.Split(block, string(lineBreak)) {
+			if line = strings.Join(strings.Fields(line), " "); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
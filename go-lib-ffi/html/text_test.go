@@ -0,0 +1,144 @@
+package html
+
+import "testing"
+
+func TestExtractText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "inline elements join words",
+			input:    "<p><b>Hel</b>lo <i>World</i></p>",
+			expected: "Hello World",
+		},
+		{
+			name:     "blocks are separated",
+			input:    "<div>First</div><div>Second</div><ul><li>One</li><li>Two</li></ul>",
+			expected: "First Second One Two",
+		},
+		{
+			name:     "invisible elements skipped",
+			input:    "<html><head><title>T</title><style>p{}</style></head><body><script>x()</script><p>Visible</p></body></html>",
+			expected: "Visible",
+		},
+		{
+			name:     "whitespace collapsed",
+			input:    "<p>  lots\n\n of \t space  </p>",
+			expected: "lots of space",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractText(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractText() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractTextPreserveBlocks(t *testing.T) {
+	page := `<html><head><title>T</title></head><body>
+		<h1>Release  notes</h1>
+		<p>Version 2 is <b>out</b>.
+		   It is faster.</p>
+		<ul><li>New   parser</li><li>Fewer <i>allocations</i></li></ul>
+		<p>Thanks,<br>The team</p>
+	</body></html>`
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     TextOptions
+		expected string
+	}{
+		{
+			name:     "collapsed by default",
+			input:    page,
+			expected: "Release notes Version 2 is out. It is faster. New parser Fewer allocations Thanks, The team",
+		},
+		{
+			name:     "blocks preserved",
+			input:    page,
+			opts:     TextOptions{PreserveBlocks: true},
+			expected: "Release notes\n\nVersion 2 is out. It is faster.\n\nNew parser\n\nFewer allocations\n\nThanks,\nThe team",
+		},
+		{
+			name:     "nested blocks produce a single blank line",
+			input:    "<div><div><p>One</p></div></div><div><p>Two</p></div>",
+			opts:     TextOptions{PreserveBlocks: true},
+			expected: "One\n\nTwo",
+		},
+		{
+			name:     "inline text without blocks",
+			input:    "Just <em>inline</em>   text",
+			opts:     TextOptions{PreserveBlocks: true},
+			expected: "Just inline text",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			opts:     TextOptions{PreserveBlocks: true},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTextWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ExtractTextWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractTextStripInvisible(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     TextOptions
+		expected string
+	}{
+		{
+			name:     "kept by default",
+			input:    "<p>token\u200bization</p>",
+			expected: "token\u200bization",
+		},
+		{
+			name:     "zero-width space removed",
+			input:    "<p>token\u200bization and&#8203;entity</p>",
+			opts:     TextOptions{StripInvisible: true},
+			expected: "tokenization andentity",
+		},
+		{
+			name:     "no double space left behind",
+			input:    "<p>one \u200b two \ufeff\x01 three</p>",
+			opts:     TextOptions{StripInvisible: true},
+			expected: "one two three",
+		},
+		{
+			name:     "line breaks kept with blocks",
+			input:    "<p>a\u200d</p><p>b<br>\x02c</p>",
+			opts:     TextOptions{StripInvisible: true, PreserveBlocks: true},
+			expected: "a\n\nb\nc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTextWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ExtractTextWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
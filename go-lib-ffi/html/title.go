@@ -0,0 +1,60 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ExtractTitle returns the document title from <title>, falling back to the
+// first <h1> when the title is missing or blank. Whitespace is collapsed.
+func ExtractTitle(htmlStr string) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	return extractTitle(doc)
+}
+
+// extractTitle finds the title of a parsed document.
+// <title> elements inside inline SVG name the graphic, not the page.
+func extractTitle(doc *html.Node) string {
+	for _, title := range findElements(doc, "title") {
+		if title.Namespace != "" {
+			continue
+		}
+		if text := strings.Join(strings.Fields(textContent(title)), " "); text != "" {
+			return text
+		}
+	}
+
+	for _, heading := range findElements(doc, "h1") {
+		if text := extractText(heading); text != "" {
+			return text
+		}
+	}
+
+	return ""
+}
+
+// textContent concatenates every text node below n, including the raw text
+// of elements like <title> that extractText treats as invisible
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package html
+
+import "testing"
+
+func TestExtractTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "title element",
+			input:    "<html><head><title>  My \n Page </title></head><body><h1>Heading</h1></body></html>",
+			expected: "My Page",
+		},
+		{
+			name:     "entities decoded",
+			input:    "<title>Tom &amp; Jerry</title>",
+			expected: "Tom & Jerry",
+		},
+		{
+			name:     "falls back to first h1",
+			input:    "<body><h1>Main <em>heading</em></h1><h1>Second</h1></body>",
+			expected: "Main heading",
+		},
+		{
+			name:     "blank title falls back to h1",
+			input:    "<title> </title><h1>Heading</h1>",
+			expected: "Heading",
+		},
+		{
+			name:     "svg title ignored",
+			input:    "<body><svg><title>Icon</title></svg><h1>Article</h1></body>",
+			expected: "Article",
+		},
+		{
+			name:     "no title",
+			input:    "<p>Just text</p>",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTitle(tt.input)
+			if result != tt.expected {
+				t.Errorf("ExtractTitle() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlSpace holds the characters HTML treats as inter-element whitespace.
+// Unlike strings.TrimSpace it leaves &nbsp; alone, which is content.
+const htmlSpace = " \t\n\f\r"
+
+// layoutElements lists the non-rendered or table structure elements that
+// count as blocks when deciding whether whitespace between them matters
+var layoutElements = map[string]bool{
+	"html": true, "head": true, "body": true, "title": true, "meta": true,
+	"link": true, "base": true, "caption": true, "colgroup": true, "col": true,
+	"thead": true, "tbody": true, "tfoot": true,
+}
+
+// preformattedElements lists the elements whose whitespace is significant
+var preformattedElements = map[string]bool{
+	"pre": true, "textarea": true, "listing": true, "plaintext": true,
+}
+
+// dropBlockWhitespace removes whitespace-only text nodes whose neighbours
+// are block elements, such as the indentation between <p> and <div> tags.
+// A node at the start or end of its parent only goes when the parent is a
+// block too. Whitespace next to inline content, which separates words, and
+// anything inside <pre> or <textarea> is kept.
+func dropBlockWhitespace(node *html.Node) {
+	if node.Type == html.ElementNode && preformattedElements[node.Data] {
+		return
+	}
+
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.TextNode {
+			if isBlockWhitespace(child) {
+				node.RemoveChild(child)
+			}
+		} else {
+			dropBlockWhitespace(child)
+		}
+		child = next
+	}
+}
+
+// isBlockWhitespace reports whether the text node n is whitespace only and
+// sits between blocks (see dropBlockWhitespace)
+func isBlockWhitespace(n *html.Node) bool {
+	if strings.Trim(n.Data, htmlSpace) != "" {
+		return false
+	}
+
+	prev, next := n.PrevSibling, n.NextSibling
+	if (prev == nil || next == nil) && !isLayoutBlock(n.Parent) {
+		return false
+	}
+	return (prev == nil || isLayoutBlock(prev)) && (next == nil || isLayoutBlock(next))
+}
+
+// isLayoutBlock reports whether n is a block or layout element, or the
+// document itself
+func isLayoutBlock(n *html.Node) bool {
+	switch n.Type {
+	case html.DocumentNode:
+		return true
+	case html.ElementNode:
+		return blockElements[n.Data] || layoutElements[n.Data]
+	}
+	return false
+}
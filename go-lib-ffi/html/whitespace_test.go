@@ -0,0 +1,66 @@
+package html
+
+import "testing"
+
+func TestCleanHTMLDropBlockWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "indentation between blocks dropped",
+			input:    "<html>\n  <head>\n    <title>T</title>\n  </head>\n  <body>\n    <div>\n      <p>One</p>\n      <p>Two</p>\n    </div>\n  </body>\n</html>\n",
+			expected: "<html><head><title>T</title></head><body><div><p>One</p><p>Two</p></div></body></html>",
+		},
+		{
+			name:     "whitespace between inline elements kept",
+			input:    "<p><b>bold</b> <i>italic</i>\n<a href=\"/x\">link</a></p>",
+			expected: "<html><head></head><body><p><b>bold</b> <i>italic</i>\n<a href=\"/x\">link</a></p></body></html>",
+		},
+		{
+			name:     "whitespace inside inline elements kept",
+			input:    "<p>a<span> </span>b</p>\n<p><em> </em></p>",
+			expected: "<html><head></head><body><p>a<span> </span>b</p><p><em> </em></p></body></html>",
+		},
+		{
+			name:     "whitespace in pre and textarea kept",
+			input:    "<div>\n<pre>\n  line one\n\n  <b>line</b> two\n</pre>\n<textarea>\n  keep\n</textarea>\n</div>",
+			expected: "<html><head></head><body><div><pre>  line one\n\n  <b>line</b> two\n</pre>\n<textarea>  keep\n</textarea>\n</div></body></html>",
+		},
+		{
+			name:     "non-breaking space kept",
+			input:    "<div>\n<p>x</p>&nbsp;<p>y</p>\n</div>",
+			expected: "<html><head></head><body><div><p>x</p> <p>y</p></div></body></html>",
+		},
+		{
+			name:     "tables",
+			input:    "<table>\n  <tr>\n    <td>a</td>\n    <td>b</td>\n  </tr>\n</table>",
+			expected: "<html><head></head><body><table><tbody><tr><td>a</td><td>b</td></tr></tbody></table></body></html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(tt.input, CleanOptions{DropBlockWhitespace: true})
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLDropBlockWhitespaceSize(t *testing.T) {
+	input := "<html>\n  <body>\n    <article>\n      <h1>Title</h1>\n      <p>First <b>bold</b> paragraph.</p>\n" +
+		"      <ul>\n        <li>one</li>\n        <li>two</li>\n      </ul>\n      <pre>\n  indented\n</pre>\n    </article>\n  </body>\n</html>\n"
+
+	kept := CleanHTML(input)
+	dropped := CleanHTMLWithOptions(input, CleanOptions{DropBlockWhitespace: true})
+
+	if len(dropped) >= len(kept) {
+		t.Errorf("CleanHTMLWithOptions() failed\nExpected: output smaller than %d bytes\nGot:      %d bytes", len(kept), len(dropped))
+	}
+	if ConvertHTMLToMarkdown(dropped) != ConvertHTMLToMarkdown(kept) {
+		t.Errorf("CleanHTMLWithOptions() failed\nExpected: same markdown as CleanHTML\nGot:      %q\nWant:     %q", ConvertHTMLToMarkdown(dropped), ConvertHTMLToMarkdown(kept))
+	}
+}
@@ -0,0 +1,35 @@
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// softHyphen marks a hyphenation opportunity that is invisible unless the
+// word wraps there. Like <wbr>, it must not split the word in extracted text.
+const softHyphen = "\u00ad"
+
+// removeWordBreaks removes <wbr> elements through remove and soft hyphens
+// below node so words split by them read as single tokens
+func removeWordBreaks(node *html.Node, remove removeFunc) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch child.Type {
+		case html.ElementNode:
+			if child.Data == "wbr" {
+				remove(child, RemovalRuleTag)
+			} else {
+				removeWordBreaks(child, remove)
+			}
+		case html.TextNode:
+			if strings.Contains(child.Data, softHyphen) {
+				// Empty text nodes break the markdown converter, so drop them
+				if child.Data = strings.ReplaceAll(child.Data, softHyphen, ""); child.Data == "" {
+					node.RemoveChild(child)
+				}
+			}
+		}
+		child = next
+	}
+}
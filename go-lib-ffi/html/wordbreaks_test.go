@@ -0,0 +1,77 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractTextWordBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     TextOptions
+		expected string
+	}{
+		{
+			name:     "wbr rejoined",
+			input:    `<p>Call Ext<wbr>ract<wbr/>Text on it</p>`,
+			expected: "Call ExtractText on it",
+		},
+		{
+			name:     "soft hyphens stripped",
+			input:    "<p>super\u00adcali\u00adfragilistic word</p>",
+			expected: "supercalifragilistic word",
+		},
+		{
+			name:     "entity soft hyphen stripped",
+			input:    `<p>inter&shy;nation&#173;alization</p>`,
+			expected: "internationalization",
+		},
+		{
+			name:     "blocks mode",
+			input:    "<p>long\u00adword</p><p>Next<wbr>Word</p>",
+			opts:     TextOptions{PreserveBlocks: true},
+			expected: "longword\n\nNextWord",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTextWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("ExtractTextWithOptions() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanHTMLWordBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "wbr removed",
+			input:    `<p>Ext<wbr>ract<wbr>Text</p>`,
+			expected: `<p>ExtractText</p>`,
+		},
+		{
+			name:     "soft hyphen only text removed",
+			input:    "<p><b>\u00ad</b>long\u00adword</p>",
+			expected: `<p><b></b>longword</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanHTMLWithOptions(tt.input, CleanOptions{Fragment: true})
+			if result != tt.expected {
+				t.Errorf("CleanHTMLWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+			if strings.Contains(ConvertHTMLToMarkdown(tt.input), "\u00ad") {
+				t.Errorf("ConvertHTMLToMarkdown() failed\nInput:    %s\nExpected: no soft hyphens", tt.input)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 /*
+#include <stdint.h>
 #include <stdlib.h>
 */
 import "C"
@@ -9,11 +10,19 @@ import (
 	"encoding/json"
 	"unsafe"
 
+	"go-lib-ffi/buildinfo"
 	"go-lib-ffi/html"
 	"go-lib-ffi/markdown"
 	"go-lib-ffi/search"
+	"go-lib-ffi/textutil"
 )
 
+// limitedResult is the JSON shape returned by the *Limited exports
+type limitedResult struct {
+	Output string `json:"output"`
+	textutil.TruncateReport
+}
+
 // CleanHTML removes noisy elements from HTML and returns cleaned HTML string.
 // The returned string must be freed by calling FreeString.
 // Returns empty string on error.
@@ -44,6 +53,329 @@ func ConvertHTMLToMarkdown(htmlStr *C.char) *C.char {
 	return C.CString(markdown)
 }
 
+// goStringUTF16 decodes length UTF-16LE code units at data to a Go string.
+// A NULL data or a length of 0 or less gives an empty string.
+func goStringUTF16(data *C.uint16_t, length C.int) string {
+	if data == nil || length <= 0 {
+		return ""
+	}
+	return textutil.DecodeUTF16LE(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(length)*2))
+}
+
+// CleanHTMLUTF16 cleans HTML like CleanHTML from length UTF-16LE code units,
+// for hosts such as .NET whose strings are UTF-16. A leading byte order mark
+// is skipped. The result is UTF-8.
+// The returned string must be freed by calling FreeString.
+//
+//export CleanHTMLUTF16
+func CleanHTMLUTF16(data *C.uint16_t, length C.int) *C.char {
+	return C.CString(html.CleanHTML(goStringUTF16(data, length)))
+}
+
+// ConvertHTMLToMarkdownUTF16 converts HTML to markdown like
+// ConvertHTMLToMarkdown from length UTF-16LE code units. The result is UTF-8.
+// The returned string must be freed by calling FreeString.
+//
+//export ConvertHTMLToMarkdownUTF16
+func ConvertHTMLToMarkdownUTF16(data *C.uint16_t, length C.int) *C.char {
+	return C.CString(html.ConvertHTMLToMarkdown(goStringUTF16(data, length)))
+}
+
+// ReaderMode returns the readable markdown of an article page: main content
+// only, without navigation or hidden elements, lazy images fixed and relative
+// links resolved against baseURL (NULL or empty leaves them relative).
+// The returned string must be freed by calling FreeString.
+// Returns empty string on error.
+//
+//export ReaderMode
+func ReaderMode(htmlStr *C.char, baseURL *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("")
+	}
+
+	var goBase string
+	if baseURL != nil {
+		goBase = C.GoString(baseURL)
+	}
+	return C.CString(html.ReaderMode(C.GoString(htmlStr), goBase))
+}
+
+// guardedCleanResult is the JSON shape returned by CleanHTMLGuarded
+type guardedCleanResult struct {
+	Output string `json:"output"`
+	Error  string `json:"error"`
+}
+
+// guardedSearchResult is the JSON shape returned by ParseSearchResultsGuarded
+type guardedSearchResult struct {
+	Results []search.SearchResult `json:"results"`
+	Error   string                `json:"error"`
+}
+
+// PreviewClean reports the elements CleanHTML would remove without cleaning.
+// optionsJSON holds clean options such as {"dedupe_blocks": true}; NULL or an
+// empty string selects the defaults.
+// Returns a JSON array of {"tag", "rule", "preview"} where rule is "tag",
+// "aria_hidden", "duplicate" or "empty_container".
+// The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error or invalid options.
+//
+//export PreviewClean
+func PreviewClean(htmlStr *C.char, optionsJSON *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("[]")
+	}
+
+	var opts html.CleanOptions
+	if optionsJSON != nil {
+		if goOptions := C.GoString(optionsJSON); goOptions != "" {
+			if err := json.Unmarshal([]byte(goOptions), &opts); err != nil {
+				return C.CString("[]")
+			}
+		}
+	}
+
+	goHTML := C.GoString(htmlStr)
+	jsonBytes, err := json.Marshal(html.PreviewClean(goHTML, opts))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// CleanHTMLGuarded cleans HTML like CleanHTML but rejects inputs longer than
+// maxBytes or with more than maxNodes nodes before parsing them.
+// A limit of 0 or less disables it.
+// Returns JSON {"output", "error"} where error is "", "input_too_large" or "too_many_nodes".
+// The returned string must be freed by calling FreeString.
+//
+//export CleanHTMLGuarded
+func CleanHTMLGuarded(htmlStr *C.char, maxBytes C.int, maxNodes C.int) *C.char {
+	if htmlStr == nil {
+		return C.CString(`{"output":"","error":""}`)
+	}
+
+	goHTML := C.GoString(htmlStr)
+	opts := html.CleanOptions{
+		Limits: textutil.Limits{MaxInputBytes: int(maxBytes), MaxNodes: int(maxNodes)},
+	}
+	cleaned, limitErr := html.CleanHTMLChecked(goHTML, opts)
+
+	jsonBytes, err := json.Marshal(guardedCleanResult{Output: cleaned, Error: textutil.LimitErrorCode(limitErr)})
+	if err != nil {
+		return C.CString(`{"output":"","error":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// CleanHTMLLimited cleans HTML and truncates the output to at most maxBytes.
+// Returns JSON {"output", "truncated", "original_bytes", "cut_bytes"}.
+// The returned string must be freed by calling FreeString.
+//
+//export CleanHTMLLimited
+func CleanHTMLLimited(htmlStr *C.char, maxBytes C.int) *C.char {
+	if htmlStr == nil {
+		return marshalLimited("", textutil.TruncateReport{})
+	}
+
+	goHTML := C.GoString(htmlStr)
+	cleaned, report := html.CleanHTMLLimited(goHTML, int(maxBytes))
+	return marshalLimited(cleaned, report)
+}
+
+// ConvertHTMLToMarkdownLimited converts HTML to markdown and truncates the output
+// to at most maxBytes without leaving a code fence open.
+// Returns JSON {"output", "truncated", "original_bytes", "cut_bytes"}.
+// The returned string must be freed by calling FreeString.
+//
+//export ConvertHTMLToMarkdownLimited
+func ConvertHTMLToMarkdownLimited(htmlStr *C.char, maxBytes C.int) *C.char {
+	if htmlStr == nil {
+		return marshalLimited("", textutil.TruncateReport{})
+	}
+
+	goHTML := C.GoString(htmlStr)
+	markdown, report := html.ConvertHTMLToMarkdownLimited(goHTML, int(maxBytes))
+	return marshalLimited(markdown, report)
+}
+
+// marshalLimited encodes a truncated output and its report as JSON
+func marshalLimited(output string, report textutil.TruncateReport) *C.char {
+	jsonBytes, err := json.Marshal(limitedResult{Output: output, TruncateReport: report})
+	if err != nil {
+		return C.CString(`{"output":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// DetectLanguage returns the ISO 639-1 code of the document language,
+// taken from <html lang> or guessed from the visible text.
+// The returned string must be freed by calling FreeString.
+// Returns empty string if the language cannot be determined.
+//
+//export DetectLanguage
+func DetectLanguage(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	return C.CString(html.DetectLanguage(goHTML))
+}
+
+// ExtractMainContent returns the cleaned inner HTML of the main content element
+// (<main>, <article> or the container with the most paragraph text), falling
+// back to the whole cleaned body when it has fewer than minChars characters of text.
+// A minChars of 0 uses the default of 200; a negative value disables the fallback.
+// The returned string must be freed by calling FreeString.
+//
+//export ExtractMainContent
+func ExtractMainContent(htmlStr *C.char, minChars C.int) *C.char {
+	if htmlStr == nil {
+		return C.CString("")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	opts := html.MainContentOptions{MinContentChars: int(minChars)}
+	return C.CString(html.ExtractMainContentWithOptions(goHTML, opts))
+}
+
+// ContentFingerprint returns a SHA-256 hex digest of the meaningful text of a
+// document, equal for copies of the same content with different ads or navigation.
+// The returned string must be freed by calling FreeString.
+// Returns empty string if the document has no text.
+//
+//export ContentFingerprint
+func ContentFingerprint(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	return C.CString(html.ContentFingerprint(goHTML))
+}
+
+// ProcessPage returns the cleaned HTML, markdown, title and visible text of a
+// document in one call, parsing it only once.
+// Returns JSON {"cleaned_html", "markdown", "title", "text"}.
+// The returned string must be freed by calling FreeString.
+//
+//export ProcessPage
+func ProcessPage(htmlStr *C.char) *C.char {
+	var goHTML string
+	if htmlStr != nil {
+		goHTML = C.GoString(htmlStr)
+	}
+
+	jsonBytes, err := json.Marshal(html.ProcessPage(goHTML))
+	if err != nil {
+		return C.CString(`{"cleaned_html":"","markdown":"","title":"","text":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// SplitHTMLByHeadings splits an HTML document into sections at <h1>-<h6>.
+// Returns a JSON array of {"heading", "level", "html"}; content before the first
+// heading is a section with an empty heading and level 0.
+// The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error.
+//
+//export SplitHTMLByHeadings
+func SplitHTMLByHeadings(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("[]")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	jsonBytes, err := json.Marshal(html.SplitHTMLByHeadings(goHTML))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ExtractBetweenComments returns the HTML between the comment markers
+// startMarker and endMarker, e.g. "article-start" and "article-end".
+// The returned string must be freed by calling FreeString.
+// Returns empty string if the markers are missing.
+//
+//export ExtractBetweenComments
+func ExtractBetweenComments(htmlStr *C.char, startMarker *C.char, endMarker *C.char) *C.char {
+	if htmlStr == nil || startMarker == nil || endMarker == nil {
+		return C.CString("")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	return C.CString(html.ExtractBetweenComments(goHTML, C.GoString(startMarker), C.GoString(endMarker)))
+}
+
+// ExtractCanonical returns the canonical and AMP links of a document.
+// Returns JSON {"canonical", "amp", "is_amp"} where canonical and amp are the
+// hrefs of <link rel="canonical"> and <link rel="amphtml">, empty when absent,
+// and is_amp reports whether the document is an AMP page itself.
+// The returned string must be freed by calling FreeString.
+//
+//export ExtractCanonical
+func ExtractCanonical(htmlStr *C.char) *C.char {
+	var goHTML string
+	if htmlStr != nil {
+		goHTML = C.GoString(htmlStr)
+	}
+
+	jsonBytes, err := json.Marshal(html.ExtractCanonicalLinks(goHTML))
+	if err != nil {
+		return C.CString(`{"canonical":"","amp":"","is_amp":false}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ExtractSummary returns a compact preview of a page from its metadata and body.
+// Text fields longer than maxChars are shortened; 0 or less keeps them whole.
+// Returns JSON {"title", "description", "first_paragraph", "image", "published"}.
+// The returned string must be freed by calling FreeString.
+//
+//export ExtractSummary
+func ExtractSummary(htmlStr *C.char, maxChars C.int) *C.char {
+	if htmlStr == nil {
+		return C.CString(`{"title":"","description":"","first_paragraph":"","image":"","published":""}`)
+	}
+
+	goHTML := C.GoString(htmlStr)
+	jsonBytes, err := json.Marshal(html.ExtractSummary(goHTML, int(maxChars)))
+	if err != nil {
+		return C.CString(`{"title":"","description":"","first_paragraph":"","image":"","published":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ExtractTables returns every table of an HTML document as data.
+// Returns a JSON array of tables, each an array of rows of cell texts; cells
+// spanning several rows or columns are repeated in every position they cover.
+// The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error.
+//
+//export ExtractTables
+func ExtractTables(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("[]")
+	}
+
+	goHTML := C.GoString(htmlStr)
+	jsonBytes, err := json.Marshal(html.ExtractTables(goHTML))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
 // ParseSearchResults parses DuckDuckGo search results HTML.
 // Returns JSON array of search results. The returned string must be freed by calling FreeString.
 // Returns empty JSON array on error.
@@ -71,6 +403,151 @@ func ParseSearchResults(htmlStr *C.char, maxResults C.int) *C.char {
 	return C.CString(string(jsonBytes))
 }
 
+// ParseSearchResultsEngine parses search results HTML with the parser of the
+// named engine: "duckduckgo", "lite" (DuckDuckGo Lite), "google", "bing" or
+// "reddit" (see GetCapabilities for the "engine:" entries).
+// Returns JSON array of search results, or an empty array for unknown engines.
+// The returned string must be freed by calling FreeString.
+//
+//export ParseSearchResultsEngine
+func ParseSearchResultsEngine(engine *C.char, htmlStr *C.char, maxResults C.int) *C.char {
+	if engine == nil || htmlStr == nil {
+		return C.CString("[]")
+	}
+
+	goEngine := C.GoString(engine)
+	goHTML := C.GoString(htmlStr)
+	max := int(maxResults)
+	if max <= 0 {
+		max = 20
+	}
+
+	results, _ := search.ParseEngineResults(goEngine, goHTML, max)
+
+	jsonBytes, err := json.Marshal(results)
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ParseSearchResultsGuarded parses DuckDuckGo search results like ParseSearchResults
+// but rejects inputs longer than maxBytes or with more than maxNodes nodes before
+// parsing them. A limit of 0 or less disables it.
+// Returns JSON {"results", "error"} where error is "", "input_too_large" or "too_many_nodes".
+// The returned string must be freed by calling FreeString.
+//
+//export ParseSearchResultsGuarded
+func ParseSearchResultsGuarded(htmlStr *C.char, maxResults C.int, maxBytes C.int, maxNodes C.int) *C.char {
+	if htmlStr == nil {
+		return C.CString(`{"results":[],"error":""}`)
+	}
+
+	goHTML := C.GoString(htmlStr)
+	max := int(maxResults)
+	if max <= 0 {
+		max = 20
+	}
+
+	opts := search.SearchOptions{
+		Limits: textutil.Limits{MaxInputBytes: int(maxBytes), MaxNodes: int(maxNodes)},
+	}
+	results, limitErr := search.ParseSearchResultsChecked(goHTML, max, opts)
+	if results == nil {
+		results = []search.SearchResult{}
+	}
+
+	jsonBytes, err := json.Marshal(guardedSearchResult{Results: results, Error: textutil.LimitErrorCode(limitErr)})
+	if err != nil {
+		return C.CString(`{"results":[],"error":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// RegisterRedirectPattern teaches the search result parsers a custom redirect
+// wrapper: links whose host contains hostContains carry their target in the
+// query parameter paramName. An empty hostContains matches any host.
+//
+//export RegisterRedirectPattern
+func RegisterRedirectPattern(hostContains *C.char, paramName *C.char) {
+	if paramName == nil {
+		return
+	}
+
+	var goHost string
+	if hostContains != nil {
+		goHost = C.GoString(hostContains)
+	}
+	search.RegisterRedirectPattern(goHost, C.GoString(paramName))
+}
+
+// GroupResultsByDomain groups a JSON array of search results, as returned by
+// ParseSearchResults, by the registrable domain of their links.
+// Returns JSON array of {"domain", "results"} groups, or an empty array for
+// invalid input. The returned string must be freed by calling FreeString.
+//
+//export GroupResultsByDomain
+func GroupResultsByDomain(resultsJSON *C.char) *C.char {
+	if resultsJSON == nil {
+		return C.CString("[]")
+	}
+
+	var results []search.SearchResult
+	if err := json.Unmarshal([]byte(C.GoString(resultsJSON)), &results); err != nil {
+		return C.CString("[]")
+	}
+
+	jsonBytes, err := json.Marshal(search.GroupResultsByDomain(results))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// searchPageDetection is the JSON shape returned by IsSearchResultsPage
+type searchPageDetection struct {
+	Detected bool   `json:"detected"`
+	Engine   string `json:"engine"`
+}
+
+// IsSearchResultsPage reports whether HTML is a search engine results page,
+// recognized by DuckDuckGo, Google or Bing result containers.
+// Returns JSON {"detected", "engine"} where engine is "duckduckgo", "google",
+// "bing" or "" when not detected.
+// The returned string must be freed by calling FreeString.
+//
+//export IsSearchResultsPage
+func IsSearchResultsPage(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString(`{"detected":false,"engine":""}`)
+	}
+
+	engine := search.DetectSearchEngine(C.GoString(htmlStr))
+	jsonBytes, err := json.Marshal(searchPageDetection{Detected: engine != "", Engine: engine})
+	if err != nil {
+		return C.CString(`{"detected":false,"engine":""}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// CleanTrackingParams removes tracking query parameters (utm_*, gclid, fbclid, ...)
+// from a URL, preserving the remaining query and the fragment.
+// The returned string must be freed by calling FreeString.
+//
+//export CleanTrackingParams
+func CleanTrackingParams(urlStr *C.char) *C.char {
+	if urlStr == nil {
+		return C.CString("")
+	}
+
+	goURL := C.GoString(urlStr)
+	return C.CString(search.CleanTrackingParams(goURL))
+}
+
 // StripMarkdown converts markdown text to plain text by removing all formatting.
 // Preserves semantic content (link text, image alt text, code) and basic structure.
 // The returned string must be freed by calling FreeString.
@@ -87,6 +564,132 @@ func StripMarkdown(markdownStr *C.char) *C.char {
 	return C.CString(plainText)
 }
 
+// StripMarkdownUTF16 converts markdown to plain text like StripMarkdown from
+// length UTF-16LE code units. The result is UTF-8.
+// The returned string must be freed by calling FreeString.
+//
+//export StripMarkdownUTF16
+func StripMarkdownUTF16(data *C.uint16_t, length C.int) *C.char {
+	return C.CString(markdown.StripMarkdown(goStringUTF16(data, length)))
+}
+
+// ExtractTablesFromMarkdown returns every GFM table of a markdown document as data.
+// Returns a JSON array of tables, each an array of rows of plain-text cells,
+// header row first. The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error.
+//
+//export ExtractTablesFromMarkdown
+func ExtractTablesFromMarkdown(markdownStr *C.char) *C.char {
+	if markdownStr == nil {
+		return C.CString("[]")
+	}
+
+	goMarkdown := C.GoString(markdownStr)
+	jsonBytes, err := json.Marshal(markdown.ExtractTablesFromMarkdown(goMarkdown))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ParseHTMLBlocks splits the body of an HTML document into typed blocks like
+// ParseMarkdownBlocks, without converting it to markdown first.
+// Returns a JSON array of {"type", "text", "level", "language"} blocks.
+// The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error.
+//
+//export ParseHTMLBlocks
+func ParseHTMLBlocks(htmlStr *C.char) *C.char {
+	if htmlStr == nil {
+		return C.CString("[]")
+	}
+
+	jsonBytes, err := json.Marshal(html.ParseHTMLBlocks(C.GoString(htmlStr)))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// ParseMarkdownBlocks splits a markdown document into its top-level blocks.
+// Returns a JSON array of {"type", "text", "level", "language"} blocks where
+// type is "heading", "paragraph", "list", "code" or "quote".
+// The returned string must be freed by calling FreeString.
+// Returns empty JSON array on error.
+//
+//export ParseMarkdownBlocks
+func ParseMarkdownBlocks(markdownStr *C.char) *C.char {
+	if markdownStr == nil {
+		return C.CString("[]")
+	}
+
+	jsonBytes, err := json.Marshal(markdown.ParseMarkdownBlocks(C.GoString(markdownStr)))
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// RemoveStopwords removes common stopwords of lang (an ISO 639-1 code such as
+// "en" or "de-DE") from text, keeping punctuation and line breaks.
+// Text in an unsupported language is returned unchanged.
+// The returned string must be freed by calling FreeString.
+//
+//export RemoveStopwords
+func RemoveStopwords(text *C.char, lang *C.char) *C.char {
+	if text == nil {
+		return C.CString("")
+	}
+
+	goText := C.GoString(text)
+	var goLang string
+	if lang != nil {
+		goLang = C.GoString(lang)
+	}
+	return C.CString(textutil.RemoveStopwords(goText, goLang))
+}
+
+// statsOps lists the operations ProcessWithStats can run, by name
+var statsOps = map[string]func(string) string{
+	"clean_html":          html.CleanHTML,
+	"convert_markdown":    html.ConvertHTMLToMarkdown,
+	"extract_text":        html.ExtractText,
+	"detect_language":     html.DetectLanguage,
+	"content_fingerprint": html.ContentFingerprint,
+	"strip_markdown":      markdown.StripMarkdown,
+	"minify_markdown":     markdown.MinifyMarkdown,
+	"tracking_params":     search.CleanTrackingParams,
+}
+
+// ProcessWithStats runs the operation named op on input and reports how it went,
+// for monitoring. Operations are "clean_html", "convert_markdown", "extract_text",
+// "detect_language", "content_fingerprint", "strip_markdown", "minify_markdown"
+// and "tracking_params".
+// Returns JSON {"result", "input_bytes", "output_bytes", "duration_us"}, with
+// "error": "unknown_operation" and no result when op is not one of them.
+// The returned string must be freed by calling FreeString.
+//
+//export ProcessWithStats
+func ProcessWithStats(op *C.char, input *C.char) *C.char {
+	var goOp, goInput string
+	if op != nil {
+		goOp = C.GoString(op)
+	}
+	if input != nil {
+		goInput = C.GoString(input)
+	}
+
+	jsonBytes, err := json.Marshal(textutil.MeasureOp(statsOps, goOp, goInput))
+	if err != nil {
+		return C.CString(`{"result":"","input_bytes":0,"output_bytes":0,"duration_us":0}`)
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
 // FreeString frees memory allocated by functions returning *C.char.
 // Must be called on all returned strings to prevent memory leaks.
 //
@@ -97,12 +700,78 @@ func FreeString(str *C.char) {
 	}
 }
 
+// stringArenas owns the strings allocated with ArenaCString
+var stringArenas = textutil.NewArenas(func(p unsafe.Pointer) { C.free(p) })
+
+// NewStringArena creates an arena for strings that are freed together with
+// FreeArena instead of one by one with FreeString. Returns its handle.
+//
+//export NewStringArena
+func NewStringArena() C.int {
+	return C.int(stringArenas.New())
+}
+
+// ArenaCString copies str into a new string owned by arena. The copy stays
+// valid until FreeArena is called and must not be passed to FreeString.
+// Returns NULL if arena is not a live handle from NewStringArena.
+//
+//export ArenaCString
+func ArenaCString(arena C.int, str *C.char) *C.char {
+	var goStr string
+	if str != nil {
+		goStr = C.GoString(str)
+	}
+
+	cStr := C.CString(goStr)
+	if !stringArenas.Track(int(arena), unsafe.Pointer(cStr)) {
+		C.free(unsafe.Pointer(cStr))
+		return nil
+	}
+	return cStr
+}
+
+// FreeArena frees every string allocated in arena and invalidates its handle.
+// Unknown handles are ignored.
+//
+//export FreeArena
+func FreeArena(arena C.int) {
+	stringArenas.Free(int(arena))
+}
+
 // GetLibraryVersion returns the current version of the library.
 // The returned string must be freed by calling FreeString.
 //
 //export GetLibraryVersion
 func GetLibraryVersion() *C.char {
-	return C.CString("1.1.0")
+	return C.CString(buildinfo.Version)
+}
+
+// GetBuildInfo describes the loaded build of the library.
+// Returns JSON {"version", "go_version", "commit", "features"}.
+// The returned string must be freed by calling FreeString.
+//
+//export GetBuildInfo
+func GetBuildInfo() *C.char {
+	jsonBytes, err := json.Marshal(buildinfo.Get())
+	if err != nil {
+		return C.CString("{}")
+	}
+
+	return C.CString(string(jsonBytes))
+}
+
+// GetCapabilities lists the features and search engines compiled into the library.
+// Returns a JSON array of names such as "clean_html" or "engine:duckduckgo".
+// The returned string must be freed by calling FreeString.
+//
+//export GetCapabilities
+func GetCapabilities() *C.char {
+	jsonBytes, err := json.Marshal(buildinfo.Capabilities())
+	if err != nil {
+		return C.CString("[]")
+	}
+
+	return C.CString(string(jsonBytes))
 }
 
 func main() {
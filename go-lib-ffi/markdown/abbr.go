@@ -0,0 +1,49 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	// abbrOpenTag matches an opening <abbr> tag
+	abbrOpenTag = regexp.MustCompile(`(?i)^<abbr(?:\s[^>]*)?>$`)
+
+	// abbrTitleAttr captures the quoted value of a title attribute
+	abbrTitleAttr = regexp.MustCompile(`(?i)\stitle\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	// abbrCloseTag matches a closing </abbr> tag
+	abbrCloseTag = regexp.MustCompile(`(?i)^</abbr\s*>$`)
+)
+
+// rawHTMLText returns the source text of an inline HTML node
+func rawHTMLText(node *ast.RawHTML, source []byte) string {
+	var sb strings.Builder
+	for i := 0; i < node.Segments.Len(); i++ {
+		segment := node.Segments.At(i)
+		sb.Write(segment.Value(source))
+	}
+	return sb.String()
+}
+
+// abbrOpenTagTitle reports whether tag opens an <abbr> element
+// and returns its unescaped title, which may be empty
+func abbrOpenTagTitle(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if !abbrOpenTag.MatchString(tag) {
+		return "", false
+	}
+	match := abbrTitleAttr.FindStringSubmatch(tag)
+	if match == nil {
+		return "", true
+	}
+	return html.UnescapeString(match[1] + match[2]), true
+}
+
+// isAbbrCloseTag reports whether tag closes an <abbr> element
+func isAbbrCloseTag(tag string) bool {
+	return abbrCloseTag.MatchString(strings.TrimSpace(tag))
+}
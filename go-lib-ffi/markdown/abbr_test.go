@@ -0,0 +1,48 @@
+package markdown
+
+import "testing"
+
+func TestStripMarkdownAbbrExpansion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expand   bool
+		expected string
+	}{
+		{
+			name:     "abbr with title expanded",
+			input:    `Written in <abbr title="HyperText Markup Language">HTML</abbr> today`,
+			expand:   true,
+			expected: "Written in HTML (HyperText Markup Language) today",
+		},
+		{
+			name:     "abbr with title kept short by default",
+			input:    `Written in <abbr title="HyperText Markup Language">HTML</abbr> today`,
+			expand:   false,
+			expected: "Written in HTML today",
+		},
+		{
+			name:     "abbr without title",
+			input:    `The <abbr>NASA</abbr> mission`,
+			expand:   true,
+			expected: "The NASA mission",
+		},
+		{
+			name:     "single quoted title with entity",
+			input:    `<abbr class="x" title='Research &amp; Development'>RnD</abbr> team`,
+			expand:   true,
+			expected: "RnD (Research & Development) team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.AbbrExpansion = tt.expand
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func init() {
+	buildinfo.Register("markdown_blocks")
+}
+
+// Block types returned by ParseMarkdownBlocks
+const (
+	BlockHeading   = "heading"
+	BlockParagraph = "paragraph"
+	BlockList      = "list"
+	BlockCode      = "code"
+	BlockQuote     = "quote"
+)
+
+// Block is one top-level block of a markdown document
+type Block struct {
+	// Type is one of BlockHeading, BlockParagraph, BlockList, BlockCode
+	// or BlockQuote
+	Type string `json:"type"`
+
+	// Text is the plain text of the block. List items are written one per
+	// line, nested items indented by two spaces; quotes separate their
+	// paragraphs with a blank line; code keeps its content verbatim.
+	Text string `json:"text"`
+
+	// Level is the heading level (1-6), 0 for other blocks
+	Level int `json:"level,omitempty"`
+
+	// Language is the info string language of a fenced code block
+	Language string `json:"language,omitempty"`
+}
+
+// ParseMarkdownBlocks splits a markdown document into its top-level blocks
+// in document order, for structured ingestion. Inline formatting is stripped
+// like ExtractTablesFromMarkdown does for cells. Thematic breaks, tables and
+// HTML blocks are skipped, as are blocks without text.
+func ParseMarkdownBlocks(source string) []Block {
+	blocks := []Block{}
+	if strings.TrimSpace(source) == "" {
+		return blocks
+	}
+
+	src := []byte(source)
+	doc := markdownConverter().Parser().Parse(text.NewReader(src))
+
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		var block Block
+		switch node := n.(type) {
+		case *ast.Heading:
+			block = Block{Type: BlockHeading, Text: cellText(node, src), Level: node.Level}
+		case *ast.Paragraph, *ast.TextBlock:
+			block = Block{Type: BlockParagraph, Text: cellText(node, src)}
+		case *ast.List:
+			var lines []string
+			listLines(node, src, "", &lines)
+			block = Block{Type: BlockList, Text: strings.Join(lines, "\n")}
+		case *ast.FencedCodeBlock:
+			block = Block{Type: BlockCode, Text: codeText(node, src), Language: string(node.Language(src))}
+		case *ast.CodeBlock:
+			block = Block{Type: BlockCode, Text: codeText(node, src)}
+		case *ast.Blockquote:
+			var paragraphs []string
+			for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+				if t := cellText(child, src); t != "" {
+					paragraphs = append(paragraphs, t)
+				}
+			}
+			block = Block{Type: BlockQuote, Text: strings.Join(paragraphs, "\n\n")}
+		default:
+			continue
+		}
+
+		if block.Text != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// listLines appends one line per item of list to lines, each prefixed with
+// indent. Nested lists follow their item, indented two more spaces.
+func listLines(list *ast.List, source []byte, indent string, lines *[]string) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		var parts []string
+		var nested []*ast.List
+		for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+			if sub, ok := child.(*ast.List); ok {
+				nested = append(nested, sub)
+			} else if t := cellText(child, source); t != "" {
+				parts = append(parts, t)
+			}
+		}
+
+		*lines = append(*lines, indent+strings.Join(parts, " "))
+		for _, sub := range nested {
+			listLines(sub, source, indent+"  ", lines)
+		}
+	}
+}
+
+// codeText returns the content of a code block without its final newline
+func codeText(node ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		buf.Write(line.Value(source))
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
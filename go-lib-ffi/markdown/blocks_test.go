@@ -0,0 +1,55 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkdownBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Block
+	}{
+		{
+			name:     "empty",
+			input:    "  \n",
+			expected: []Block{},
+		},
+		{
+			name: "mixed document",
+			input: "# Getting **started**\n\nGo is a [language](https://go.dev)\nthat compiles `fast`.\n\n" +
+				"## Install\n\n- Download it\n- Run the installer\n  1. Accept\n  2. Finish\n\n" +
+				"```go\nfmt.Println(\"hi\")\n\n// done\n```\n\n" +
+				"> Simplicity is *complicated*.\n>\n> Rob Pike\n\n---\n\n" +
+				"    indented code\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\nThe end.",
+			expected: []Block{
+				{Type: BlockHeading, Text: "Getting started", Level: 1},
+				{Type: BlockParagraph, Text: "Go is a language that compiles fast."},
+				{Type: BlockHeading, Text: "Install", Level: 2},
+				{Type: BlockList, Text: "Download it\nRun the installer\n  Accept\n  Finish"},
+				{Type: BlockCode, Text: "fmt.Println(\"hi\")\n\n// done", Language: "go"},
+				{Type: BlockQuote, Text: "Simplicity is complicated.\n\nRob Pike"},
+				{Type: BlockCode, Text: "indented code"},
+				{Type: BlockParagraph, Text: "The end."},
+			},
+		},
+		{
+			name:  "setext heading and loose list",
+			input: "Title\n=====\n\n* one\n\n  more of one\n\n* two\n",
+			expected: []Block{
+				{Type: BlockHeading, Text: "Title", Level: 1},
+				{Type: BlockList, Text: "one more of one\ntwo"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseMarkdownBlocks(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseMarkdownBlocks() failed\nInput:    %q\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
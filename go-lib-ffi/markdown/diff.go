@@ -0,0 +1,115 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+)
+
+// MarkdownTextEqual reports whether two markdown documents have the same
+// plain text once formatting is stripped, ignoring differences in
+// whitespace and blank lines
+func MarkdownTextEqual(a, b string) bool {
+	return slices.Equal(textLines(a), textLines(b))
+}
+
+// MarkdownTextDiff compares the plain text of two markdown documents line by
+// line, ignoring formatting, whitespace and blank lines. It returns the
+// changed lines in order, prefixed "- " when only in a and "+ " when only
+// in b, or an empty slice when the texts are equal.
+func MarkdownTextDiff(a, b string) []string {
+	diff := []string{}
+	diffLines(textLines(a), textLines(b), &diff)
+	return diff
+}
+
+// diffLines appends the changes turning before into after to diff.
+// Common leading and trailing lines are skipped first, then the rest is
+// split around a line of before using Hirschberg's method, so memory stays
+// linear in the input instead of holding a full LCS table.
+func diffLines(before, after []string, diff *[]string) {
+	for len(before) > 0 && len(after) > 0 && before[0] == after[0] {
+		before, after = before[1:], after[1:]
+	}
+	for len(before) > 0 && len(after) > 0 && before[len(before)-1] == after[len(after)-1] {
+		before, after = before[:len(before)-1], after[:len(after)-1]
+	}
+
+	switch {
+	case len(before) == 0:
+		for _, line := range after {
+			*diff = append(*diff, "+ "+line)
+		}
+		return
+	case len(after) == 0:
+		for _, line := range before {
+			*diff = append(*diff, "- "+line)
+		}
+		return
+	case len(before) == 1:
+		// The line is known to differ from the first and last of after,
+		// so at most one line in between can match it
+		k := slices.Index(after, before[0])
+		if k < 0 {
+			*diff = append(*diff, "- "+before[0])
+			diffLines(nil, after, diff)
+			return
+		}
+		diffLines(nil, after[:k], diff)
+		diffLines(nil, after[k+1:], diff)
+		return
+	}
+
+	// Split after where the common subsequences of the two halves of
+	// before add up to the longest one
+	mid := len(before) / 2
+	forward := lcsLengths(before[:mid], after, false)
+	backward := lcsLengths(before[mid:], after, true)
+	split := 0
+	for k := range forward {
+		if forward[k]+backward[len(after)-k] > forward[split]+backward[len(after)-split] {
+			split = k
+		}
+	}
+
+	diffLines(before[:mid], after[:split], diff)
+	diffLines(before[mid:], after[split:], diff)
+}
+
+// lcsLengths returns, for every k, the length of the longest common
+// subsequence of before and the first k lines of after. When reversed is
+// set both slices are read back to front, so k counts lines from the end.
+func lcsLengths(before, after []string, reversed bool) []int {
+	at := func(lines []string, i int) string {
+		if reversed {
+			return lines[len(lines)-1-i]
+		}
+		return lines[i]
+	}
+
+	prev := make([]int, len(after)+1)
+	curr := make([]int, len(after)+1)
+	for i := range before {
+		line := at(before, i)
+		for j := range after {
+			if line == at(after, j) {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev
+}
+
+// textLines returns the non-empty lines of the stripped text of source
+// with whitespace collapsed
+func textLines(source string) []string {
+	var lines []string
+	for _, line := range strings.Split(StripMarkdown(source), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
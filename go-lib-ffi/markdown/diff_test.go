@@ -0,0 +1,118 @@
+package markdown
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownTextEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{
+			name:     "emphasis and heading style differ",
+			a:        "# Release notes\n\nThe **new** parser is *faster*.",
+			b:        "Release notes\n=============\n\nThe __new__ parser is _faster_.",
+			expected: true,
+		},
+		{
+			name:     "link targets and whitespace differ",
+			a:        "Read the [docs](https://example.com/v1).\n\n\n\n- one\n- two",
+			b:        "Read   the [docs](https://example.com/v2).\n\n* one\n* two",
+			expected: true,
+		},
+		{
+			name:     "text differs",
+			a:        "The parser is **fast**.",
+			b:        "The parser is **slow**.",
+			expected: false,
+		},
+		{
+			name:     "both empty",
+			a:        "",
+			b:        "  \n",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MarkdownTextEqual(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("MarkdownTextEqual() failed\nInput:    %q, %q\nExpected: %v\nGot:      %v", tt.a, tt.b, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarkdownTextDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected []string
+	}{
+		{
+			name:     "formatting only",
+			a:        "# Title\n\nSome **bold** text.",
+			b:        "Title\n-----\n\nSome __bold__ text.",
+			expected: []string{},
+		},
+		{
+			name:     "changed line",
+			a:        "# Title\n\nPrice: **$10**\n\nContact us.",
+			b:        "# Title\n\nPrice: *$12*\n\nContact us.",
+			expected: []string{"- Price: $10", "+ Price: $12"},
+		},
+		{
+			name:     "added and removed lines",
+			a:        "- apples\n- pears\n- plums",
+			b:        "- apples\n- plums\n- cherries",
+			expected: []string{"- - pears", "+ - cherries"},
+		},
+		{
+			name:     "changes on both sides of a common line",
+			a:        "one\n\ntwo\n\nthree\n\nfour\n\nfive",
+			b:        "uno\n\ntwo\n\nthree\n\nfive\n\nsix",
+			expected: []string{"- one", "+ uno", "- four", "+ six"},
+		},
+		{
+			name:     "against empty document",
+			a:        "",
+			b:        "Hello *world*",
+			expected: []string{"+ Hello world"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MarkdownTextDiff(tt.a, tt.b)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("MarkdownTextDiff() failed\nInput:    %q, %q\nExpected: %q\nGot:      %q", tt.a, tt.b, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarkdownTextDiffLargeInput(t *testing.T) {
+	var before, after strings.Builder
+	for i := range 3000 {
+		line := "Paragraph " + strconv.Itoa(i)
+		before.WriteString(line + "\n\n")
+		if i == 1500 {
+			line = "Paragraph changed"
+		}
+		after.WriteString(line + "\n\n")
+	}
+
+	result := MarkdownTextDiff(before.String(), after.String())
+	expected := []string{"- Paragraph 1500", "+ Paragraph changed"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("MarkdownTextDiff() failed\nInput:    3000 paragraphs with one changed\nExpected: %q\nGot:      %q", expected, result)
+	}
+}
@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// characterReference matches a named or numeric HTML character reference
+var characterReference = regexp.MustCompile(`&(#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+
+// markdownSyntax lists the characters that can start emphasis, links, code,
+// headings, lists or tables. References decoding to one are backslash
+// escaped so they stay literal text.
+const markdownSyntax = "\\`*_[]()#!|~+-.="
+
+// DecodeEntities decodes HTML character references left as literal text in
+// markdown, such as "&amp;", "&#8217;" or "&nbsp;". Non-breaking spaces,
+// encoded or not, become regular spaces. References that decode to "<" or
+// ">" are kept so the text cannot turn into raw HTML, as are unknown names,
+// and references to markdown syntax such as "&#42;" become escapes like "\*".
+// Each reference is decoded once, so "&amp;lt;" gives "&lt;". Code spans and
+// fenced code blocks are left untouched.
+func DecodeEntities(source string) string {
+	if !strings.Contains(source, "&") && !strings.Contains(source, "\u00a0") {
+		return source
+	}
+
+	lines := strings.Split(source, "\n")
+
+	fence := ""
+	for i, line := range lines {
+		marker := fenceMarker(line)
+		if fence != "" {
+			// Closing fences follow the same rule as in fenceCloser
+			if marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+				strings.TrimSpace(line) == marker {
+				fence = ""
+			}
+			continue
+		}
+		if marker != "" {
+			fence = marker
+			continue
+		}
+
+		lines[i] = outsideCodeSpans(line, decodeReferences)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// decodeReferences decodes the character references in text (see DecodeEntities)
+func decodeReferences(text string) string {
+	text = characterReference.ReplaceAllStringFunc(text, func(ref string) string {
+		decoded := html.UnescapeString(ref)
+		switch {
+		case decoded == "<" || decoded == ">":
+			return ref
+		case len(decoded) == 1 && strings.Contains(markdownSyntax, decoded):
+			return "\\" + decoded
+		}
+		return decoded
+	})
+	return strings.ReplaceAll(text, "\u00a0", " ")
+}
@@ -0,0 +1,61 @@
+package markdown
+
+import "testing"
+
+func TestDecodeEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no entities",
+			input:    "Plain *text* & more",
+			expected: "Plain *text* & more",
+		},
+		{
+			name:     "non-breaking spaces",
+			input:    "a&nbsp;b c&#160;d e",
+			expected: "a b c d e",
+		},
+		{
+			name:     "named and numeric entities",
+			input:    "Tom &amp; Jerry &copy; 2024 &#8217;quoted&#x2019; &hellip;",
+			expected: "Tom & Jerry © 2024 ’quoted’ …",
+		},
+		{
+			name:     "angle brackets stay escaped",
+			input:    "&lt;div&gt; and &#60;p&#x3E;",
+			expected: "&lt;div&gt; and &#60;p&#x3E;",
+		},
+		{
+			name:     "markdown syntax stays escaped",
+			input:    "&#42;bold&#42; &#91;x&#93;&#40;javascript:alert(1)&#41; &#35; &lowbar;i&lowbar; &#96;c&#96; &#92;",
+			expected: "\\*bold\\* \\[x\\]\\(javascript:alert(1)\\) \\# \\_i\\_ \\`c\\` \\\\",
+		},
+		{
+			name:     "decoded once",
+			input:    "&amp;lt; &amp;amp;",
+			expected: "&lt; &amp;",
+		},
+		{
+			name:     "unknown and unterminated entities kept",
+			input:    "&bogus; AT&T &amp",
+			expected: "&bogus; AT&T &amp",
+		},
+		{
+			name:     "code untouched",
+			input:    "Use `&nbsp;` &amp; more\n\n```html\n<p>&amp;&nbsp;</p>\n```\n\nafter &amp;",
+			expected: "Use `&nbsp;` & more\n\n```html\n<p>&amp;&nbsp;</p>\n```\n\nafter &",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DecodeEntities(tt.input)
+			if result != tt.expected {
+				t.Errorf("DecodeEntities() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
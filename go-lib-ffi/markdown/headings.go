@@ -0,0 +1,41 @@
+package markdown
+
+import "bytes"
+
+// collapseHeadingSpace collapses whitespace runs written after start, the
+// text of a heading, to single spaces and trims both ends, so code spans
+// padded with spaces or tabs read like the rest of the title.
+// Protected ranges are moved to match the shortened buffer.
+func collapseHeadingSpace(buf *bytes.Buffer, start int, protected [][2]int) {
+	content := buf.Bytes()[start:]
+
+	// newPos maps every offset of content to its offset after collapsing
+	newPos := make([]int, len(content)+1)
+	out := make([]byte, 0, len(content))
+	pendingSpace := false
+	for i, b := range content {
+		if isSpaceByte(b) {
+			pendingSpace = len(out) > 0
+			newPos[i] = len(out)
+			continue
+		}
+		if pendingSpace {
+			out = append(out, ' ')
+			pendingSpace = false
+		}
+		newPos[i] = len(out)
+		out = append(out, b)
+	}
+	newPos[len(content)] = len(out)
+
+	for i := range protected {
+		for j := range protected[i] {
+			if protected[i][j] >= start {
+				protected[i][j] = start + newPos[protected[i][j]-start]
+			}
+		}
+	}
+
+	buf.Truncate(start)
+	buf.Write(out)
+}
@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Highlight represents "==highlighted==" text, the markdown form of <mark>
+type Highlight struct {
+	ast.BaseInline
+}
+
+// KindHighlight is the NodeKind of the Highlight node
+var KindHighlight = ast.NewNodeKind("Highlight")
+
+// Kind implements ast.Node.Kind
+func (n *Highlight) Kind() ast.NodeKind {
+	return KindHighlight
+}
+
+// Dump implements ast.Node.Dump
+func (n *Highlight) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+type highlightDelimiterProcessor struct{}
+
+func (p *highlightDelimiterProcessor) IsDelimiter(b byte) bool {
+	return b == '='
+}
+
+func (p *highlightDelimiterProcessor) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Char == closer.Char
+}
+
+func (p *highlightDelimiterProcessor) OnMatch(consumes int) ast.Node {
+	return &Highlight{}
+}
+
+var defaultHighlightDelimiterProcessor = &highlightDelimiterProcessor{}
+
+// highlightParser parses "==text==" spans. Unlike strikethrough, exactly two
+// delimiter characters are required so comparisons like "a = b" are untouched.
+type highlightParser struct{}
+
+func (s *highlightParser) Trigger() []byte {
+	return []byte{'='}
+}
+
+func (s *highlightParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	before := block.PrecendingCharacter()
+	line, segment := block.PeekLine()
+	node := parser.ScanDelimiter(line, before, 2, defaultHighlightDelimiterProcessor)
+	if node == nil || node.OriginalLength != 2 || before == '=' {
+		return nil
+	}
+
+	node.Segment = segment.WithStop(segment.Start + node.OriginalLength)
+	block.Advance(node.OriginalLength)
+	pc.PushDelimiter(node)
+	return node
+}
+
+func (s *highlightParser) CloseBlock(parent ast.Node, pc parser.Context) {
+	// nothing to do
+}
+
+type highlightExtension struct{}
+
+// highlight is a goldmark extension parsing "==text==" into Highlight nodes.
+// It only extends the parser since the package never renders HTML.
+var highlight = &highlightExtension{}
+
+func (e *highlightExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(
+		util.Prioritized(&highlightParser{}, 500),
+	))
+}
@@ -0,0 +1,56 @@
+package markdown
+
+import "testing"
+
+func TestStripMarkdownRevisionMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "highlight",
+			input:    "A ==key== point",
+			expected: "A key point",
+		},
+		{
+			name:     "highlight with formatting inside",
+			input:    "==**very** important==",
+			expected: "very important",
+		},
+		{
+			name:     "single equals untouched",
+			input:    "x = 1 and y=2",
+			expected: "x = 1 and y=2",
+		},
+		{
+			name:     "spaced comparison untouched",
+			input:    "if a == b then",
+			expected: "if a == b then",
+		},
+		{
+			name:     "strikethrough from del",
+			input:    "Price: ~~$20~~ $15",
+			expected: "Price: $20 $15",
+		},
+		{
+			name:     "inline ins and del html",
+			input:    "Added <ins>new</ins> and <del>old</del> text",
+			expected: "Added new and old text",
+		},
+		{
+			name:     "inline mark html",
+			input:    "A <mark>key</mark> point",
+			expected: "A key point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("StripMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// inlineLink matches inline links and images on one line,
+	// e.g. [text](url "title") and ![alt](url)
+	inlineLink = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(([^)\n]*)\)`)
+
+	// referenceDefinition matches link reference definitions,
+	// e.g. [1]: url "title"
+	referenceDefinition = regexp.MustCompile(`^( {0,3}\[[^\]\n]+\]:[ \t]*)(\S+)(.*)$`)
+
+	// linkTitle matches an optional title at the end of a link target
+	linkTitle = regexp.MustCompile(`\s+("[^"]*"|'[^']*'|\([^)]*\))$`)
+
+	// urlScheme matches the scheme of an absolute URL
+	urlScheme = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*):`)
+)
+
+// LinkOptions controls optional behavior of NormalizeMarkdownLinksWithOptions
+type LinkOptions struct {
+	// DropBroken replaces broken links with their text and broken images
+	// with their alt text. By default they are only reported.
+	DropBroken bool `json:"drop_broken"`
+}
+
+// NormalizeMarkdownLinks cleans up link and image targets in markdown:
+//   - whitespace around the URL is trimmed, e.g. "[text]( url )"
+//   - schemes are lowercased, e.g. "HTTPS://" becomes "https://"
+//   - URLs starting with "www." get the https scheme
+//
+// Code spans and fenced code blocks are left untouched.
+func NormalizeMarkdownLinks(source string) string {
+	normalized, _ := NormalizeMarkdownLinksWithOptions(source, LinkOptions{})
+	return normalized
+}
+
+// NormalizeMarkdownLinksWithOptions normalizes links like NormalizeMarkdownLinks
+// and also returns the broken inline links as written in source. A link is
+// broken when its URL is empty, contains spaces or has a web scheme without
+// a host, e.g. "[text]()", "[text](a b)" or "[text](http://)".
+func NormalizeMarkdownLinksWithOptions(source string, opts LinkOptions) (string, []string) {
+	broken := []string{}
+	lines := strings.Split(source, "\n")
+
+	fence := ""
+	for i, line := range lines {
+		marker := fenceMarker(line)
+		if fence != "" {
+			// Closing fences follow the same rule as in fenceCloser
+			if marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+				strings.TrimSpace(line) == marker {
+				fence = ""
+			}
+			continue
+		}
+		if marker != "" {
+			fence = marker
+			continue
+		}
+
+		if m := referenceDefinition.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + normalizeLinkURL(m[2]) + m[3]
+			continue
+		}
+
+		lines[i] = outsideCodeSpans(line, func(text string) string {
+			return inlineLink.ReplaceAllStringFunc(text, func(link string) string {
+				m := inlineLink.FindStringSubmatch(link)
+				url, title, ok := splitLinkTarget(m[3])
+				if !ok {
+					broken = append(broken, link)
+					if opts.DropBroken {
+						return m[2]
+					}
+					return link
+				}
+				return m[1] + "[" + m[2] + "](" + url + title + ")"
+			})
+		})
+	}
+
+	return strings.Join(lines, "\n"), broken
+}
+
+// splitLinkTarget normalizes the URL inside the parentheses of an inline
+// link and returns it with the title, if any, still attached.
+// ok is false when the URL is broken.
+func splitLinkTarget(target string) (url, title string, ok bool) {
+	target = strings.TrimSpace(target)
+	if loc := linkTitle.FindStringIndex(target); loc != nil {
+		title = " " + strings.TrimSpace(target[loc[0]:])
+		target = target[:loc[0]]
+	}
+
+	if inner, found := strings.CutPrefix(target, "<"); found && strings.HasSuffix(inner, ">") {
+		inner = strings.TrimSpace(strings.TrimSuffix(inner, ">"))
+		if inner == "" {
+			return "", "", false
+		}
+		return "<" + normalizeLinkURL(inner) + ">", title, true
+	}
+
+	url = normalizeLinkURL(target)
+	if url == "" || strings.ContainsAny(url, " \t") || !hasHost(url) {
+		return "", "", false
+	}
+	return url, title, true
+}
+
+// normalizeLinkURL lowercases the scheme of url and gives "www." URLs the
+// https scheme
+func normalizeLinkURL(url string) string {
+	if loc := urlScheme.FindStringIndex(url); loc != nil {
+		return strings.ToLower(url[:loc[1]]) + url[loc[1]:]
+	}
+	if strings.HasPrefix(strings.ToLower(url), "www.") {
+		return "https://" + url
+	}
+	return url
+}
+
+// hasHost reports whether url names a host when it uses a web scheme.
+// URLs with other schemes or without one are accepted as they are.
+func hasHost(url string) bool {
+	for _, scheme := range []string{"http://", "https://", "ftp://"} {
+		if rest, ok := strings.CutPrefix(url, scheme); ok {
+			return rest != "" && rest[0] != '/'
+		}
+	}
+	return true
+}
+
+// outsideCodeSpans applies rewrite to the parts of line outside code spans
+func outsideCodeSpans(line string, rewrite func(string) string) string {
+	var sb strings.Builder
+	pos := 0
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+		end := closingBackticks(line, i+run, run)
+		if end < 0 {
+			i += run
+			continue
+		}
+		sb.WriteString(rewrite(line[pos:i]))
+		sb.WriteString(line[i:end])
+		pos, i = end, end
+	}
+	sb.WriteString(rewrite(line[pos:]))
+	return sb.String()
+}
+
+// closingBackticks returns the end of the first run of exactly n backticks
+// at or after from, or -1 when there is none
+func closingBackticks(line string, from, n int) int {
+	for i := from; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+		if run == n {
+			return i + run
+		}
+		i += run
+	}
+	return -1
+}
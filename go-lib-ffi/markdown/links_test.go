@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "spaces around URL trimmed",
+			input:    "See [docs]( https://example.com/docs ) and ![logo](\t/logo.png ).",
+			expected: "See [docs](https://example.com/docs) and ![logo](/logo.png).",
+		},
+		{
+			name:     "scheme lowercased",
+			input:    "[home](HTTPS://Example.com/Path) [mail](MailTo:me@example.com)",
+			expected: "[home](https://Example.com/Path) [mail](mailto:me@example.com)",
+		},
+		{
+			name:     "scheme-less www link gets https",
+			input:    "[site](www.example.com/about) [page](about.html)",
+			expected: "[site](https://www.example.com/about) [page](about.html)",
+		},
+		{
+			name:     "title kept",
+			input:    `[a]( https://a.example  "The A" )`,
+			expected: `[a](https://a.example "The A")`,
+		},
+		{
+			name:     "angle brackets kept",
+			input:    "[file](< /my file.txt >)",
+			expected: "[file](</my file.txt>)",
+		},
+		{
+			name:     "reference definitions normalized",
+			input:    "[a][1]\n\n[1]: HTTP://example.com \"title\"\n[2]: www.example.org",
+			expected: "[a][1]\n\n[1]: http://example.com \"title\"\n[2]: https://www.example.org",
+		},
+		{
+			name:     "code left untouched",
+			input:    "`[x]( y )` and [z]( HTTP://z.example )\n\n```\n[x]( y )\n```",
+			expected: "`[x]( y )` and [z](http://z.example)\n\n```\n[x]( y )\n```",
+		},
+		{
+			name:     "broken links kept by default",
+			input:    "[empty]() [spaced](a b) [hostless](https://)",
+			expected: "[empty]() [spaced](a b) [hostless](https://)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizeMarkdownLinks(tt.input)
+			if result != tt.expected {
+				t.Errorf("NormalizeMarkdownLinks() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeMarkdownLinksBroken(t *testing.T) {
+	input := "Read [empty]() or [spaced](some page) or [ok]( /ok ) or ![img](http:///x.png)."
+
+	tests := []struct {
+		name     string
+		opts     LinkOptions
+		expected string
+	}{
+		{
+			name:     "broken links reported",
+			expected: "Read [empty]() or [spaced](some page) or [ok](/ok) or ![img](http:///x.png).",
+		},
+		{
+			name:     "broken links dropped",
+			opts:     LinkOptions{DropBroken: true},
+			expected: "Read empty or spaced or [ok](/ok) or img.",
+		},
+	}
+	expectedBroken := []string{"[empty]()", "[spaced](some page)", "![img](http:///x.png)"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, broken := NormalizeMarkdownLinksWithOptions(input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("NormalizeMarkdownLinksWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+			if !slices.Equal(broken, expectedBroken) {
+				t.Errorf("NormalizeMarkdownLinksWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, expectedBroken, broken)
+			}
+		})
+	}
+}
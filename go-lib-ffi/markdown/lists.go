@@ -0,0 +1,33 @@
+package markdown
+
+import "github.com/yuin/goldmark/ast"
+
+// truncateLists removes the items after the first maxItems of every list
+// below doc and returns the number of items removed from each shortened list.
+// A maxItems of 0 or less keeps every item.
+func truncateLists(doc ast.Node, maxItems int) map[ast.Node]int {
+	cut := make(map[ast.Node]int)
+	if maxItems <= 0 {
+		return cut
+	}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !entering || !ok || list.ChildCount() <= maxItems {
+			return ast.WalkContinue, nil
+		}
+
+		item := list.FirstChild()
+		for i := 0; i < maxItems; i++ {
+			item = item.NextSibling()
+		}
+		for item != nil {
+			next := item.NextSibling()
+			list.RemoveChild(list, item)
+			cut[list]++
+			item = next
+		}
+		return ast.WalkContinue, nil
+	})
+	return cut
+}
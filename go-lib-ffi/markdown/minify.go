@@ -0,0 +1,375 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func init() {
+	buildinfo.Register("minify_markdown")
+}
+
+// MinifyMarkdown rewrites markdown in a canonical minimal form without
+// changing what it renders to: ATX headings, "-" bullets, fenced code blocks,
+// inline links, a single blank line between blocks and no trailing spaces.
+// The output is stable, so minifying it again returns it unchanged.
+func MinifyMarkdown(source string) string {
+	if strings.TrimSpace(source) == "" {
+		return ""
+	}
+
+	src := []byte(strings.ReplaceAll(source, "\r\n", "\n"))
+	doc := markdownConverter().Parser().Parse(text.NewReader(src))
+
+	m := &minifier{source: src}
+	return strings.TrimSpace(m.blocks(doc, "\n\n"))
+}
+
+// minifier renders a goldmark AST back to markdown
+type minifier struct {
+	source []byte
+}
+
+// blocks renders the block children of n joined by separator
+func (m *minifier) blocks(n ast.Node, separator string) string {
+	var parts []string
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if part := m.block(child); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// block renders a single block node
+func (m *minifier) block(n ast.Node) string {
+	switch node := n.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		return trimLineEnds(m.inlines(node))
+
+	case *ast.Heading:
+		content := strings.Join(strings.Fields(m.inlines(node)), " ")
+		return strings.TrimSpace(strings.Repeat("#", node.Level) + " " + content)
+
+	case *ast.ThematicBreak:
+		return "---"
+
+	case *ast.FencedCodeBlock:
+		var info string
+		if node.Info != nil {
+			info = string(node.Info.Segment.Value(m.source))
+		}
+		return m.fencedCode(node, info)
+
+	case *ast.CodeBlock:
+		return m.fencedCode(node, "")
+
+	case *ast.Blockquote:
+		return prefixLines(m.blocks(node, "\n\n"), "> ", ">")
+
+	case *ast.List:
+		return m.list(node)
+
+	case *ast.HTMLBlock:
+		var buf bytes.Buffer
+		buf.WriteString(m.lines(node))
+		if node.HasClosure() {
+			buf.Write(node.ClosureLine.Value(m.source))
+		}
+		return strings.TrimRight(buf.String(), "\n")
+
+	case *extast.Table:
+		return m.table(node)
+	}
+
+	// Unknown blocks keep their source lines
+	if n.Lines().Len() > 0 {
+		return strings.TrimRight(m.lines(n), "\n")
+	}
+	return m.blocks(n, "\n\n")
+}
+
+// lines returns the raw source lines of a block
+func (m *minifier) lines(n ast.Node) string {
+	var buf bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		buf.WriteString(strings.Repeat(" ", line.Padding))
+		buf.Write(line.Value(m.source))
+	}
+	return buf.String()
+}
+
+// fencedCode writes a code block with a backtick fence longer than any
+// backtick run at the start of its lines
+func (m *minifier) fencedCode(n ast.Node, info string) string {
+	content := m.lines(n)
+
+	fence := "```"
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		for strings.HasPrefix(trimmed, fence) {
+			fence += "`"
+		}
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return fence + info + "\n" + content + fence
+}
+
+// list renders list items with canonical markers. A list following another
+// list of the same kind uses the alternate marker so the two stay separate.
+func (m *minifier) list(list *ast.List) string {
+	bullet, delimiter := "-", "."
+	if previous, ok := list.PreviousSibling().(*ast.List); ok && previous.IsOrdered() == list.IsOrdered() {
+		bullet, delimiter = "*", ")"
+	}
+
+	separator := "\n\n"
+	if list.IsTight {
+		separator = "\n"
+	}
+
+	var items []string
+	number := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := bullet
+		if list.IsOrdered() {
+			marker = fmt.Sprintf("%d%s", number, delimiter)
+			number++
+		}
+
+		content := m.blocks(item, separator)
+		if content == "" {
+			items = append(items, marker)
+			continue
+		}
+
+		indent := strings.Repeat(" ", len(marker)+1)
+		items = append(items, marker+" "+strings.TrimPrefix(prefixLines(content, indent, ""), indent))
+	}
+
+	return strings.Join(items, separator)
+}
+
+// table renders a GFM table with a delimiter row matching its alignments
+func (m *minifier) table(table *extast.Table) string {
+	var rows []string
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, escapeCellPipes(strings.TrimSpace(m.inlines(cell))))
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+
+		if _, ok := row.(*extast.TableHeader); ok {
+			delimiters := make([]string, len(table.Alignments))
+			for i, alignment := range table.Alignments {
+				switch alignment {
+				case extast.AlignLeft:
+					delimiters[i] = ":---"
+				case extast.AlignRight:
+					delimiters[i] = "---:"
+				case extast.AlignCenter:
+					delimiters[i] = ":---:"
+				default:
+					delimiters[i] = "---"
+				}
+			}
+			rows = append(rows, "| "+strings.Join(delimiters, " | ")+" |")
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// escapeCellPipes escapes the "|" characters of a table cell that are not
+// escaped yet. The table parser unescapes pipes inside code spans.
+func escapeCellPipes(cell string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range cell {
+		if r == '|' && !escaped {
+			sb.WriteByte('\\')
+		}
+		escaped = r == '\\' && !escaped
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// inlines renders the inline children of n
+func (m *minifier) inlines(n ast.Node) string {
+	var buf bytes.Buffer
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		m.inline(&buf, child)
+	}
+	return buf.String()
+}
+
+// inline renders a single inline node into buf
+func (m *minifier) inline(buf *bytes.Buffer, n ast.Node) {
+	switch node := n.(type) {
+	case *ast.Text:
+		buf.Write(node.Segment.Value(m.source))
+		switch {
+		case node.HardLineBreak():
+			trimTrailingSpaces(buf)
+			buf.WriteString("\\\n")
+		case node.SoftLineBreak():
+			buf.WriteString("\n")
+		}
+
+	case *ast.String:
+		buf.Write(node.Value)
+
+	case *ast.CodeSpan:
+		buf.WriteString(codeSpan(m.inlineText(node)))
+
+	case *ast.Emphasis:
+		delimiter := strings.Repeat("*", node.Level)
+		buf.WriteString(delimiter + m.inlines(node) + delimiter)
+
+	case *extast.Strikethrough:
+		buf.WriteString("~~" + m.inlines(node) + "~~")
+
+	case *Highlight:
+		buf.WriteString("==" + m.inlines(node) + "==")
+
+	case *ast.Link:
+		buf.WriteString("[" + m.inlines(node) + "]" + linkTarget(node.Destination, node.Title))
+
+	case *ast.Image:
+		buf.WriteString("![" + m.inlines(node) + "]" + linkTarget(node.Destination, node.Title))
+
+	case *ast.AutoLink:
+		label := string(node.Label(m.source))
+		if isLinkifiable(node, label) {
+			buf.WriteString(label)
+		} else {
+			buf.WriteString("<" + label + ">")
+		}
+
+	case *ast.RawHTML:
+		for i := 0; i < node.Segments.Len(); i++ {
+			segment := node.Segments.At(i)
+			buf.Write(segment.Value(m.source))
+		}
+
+	case *extast.TaskCheckBox:
+		if node.IsChecked {
+			buf.WriteString("[x] ")
+		} else {
+			buf.WriteString("[ ] ")
+		}
+
+	default:
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			m.inline(buf, child)
+		}
+	}
+}
+
+// inlineText returns the raw text of the Text children of n
+func (m *minifier) inlineText(n ast.Node) string {
+	var buf bytes.Buffer
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		switch node := child.(type) {
+		case *ast.Text:
+			buf.Write(node.Segment.Value(m.source))
+		case *ast.String:
+			buf.Write(node.Value)
+		}
+	}
+	return buf.String()
+}
+
+// codeSpan wraps content in a backtick run longer than any run inside it,
+// padding with spaces when the content starts or ends with a backtick
+func codeSpan(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	delimiter := strings.Repeat("`", longest+1)
+
+	if strings.HasPrefix(content, "`") || strings.HasSuffix(content, "`") ||
+		(strings.HasPrefix(content, " ") && strings.HasSuffix(content, " ") && strings.TrimSpace(content) != "") {
+		content = " " + content + " "
+	}
+	return delimiter + content + delimiter
+}
+
+// linkTarget renders the "(destination "title")" part of a link or image
+func linkTarget(destination, title []byte) string {
+	dest := string(destination)
+	if dest == "" || strings.ContainsAny(dest, " <>()") {
+		dest = "<" + strings.NewReplacer("<", "\\<", ">", "\\>").Replace(dest) + ">"
+	}
+
+	if len(title) == 0 {
+		return "(" + dest + ")"
+	}
+	escaped := strings.NewReplacer("\\", "\\\\", `"`, `\"`).Replace(string(title))
+	return "(" + dest + ` "` + escaped + `")`
+}
+
+// isLinkifiable reports whether an autolink is recognized again when written
+// bare, as GFM links www., http(s) and ftp URLs and email addresses
+func isLinkifiable(node *ast.AutoLink, label string) bool {
+	if node.AutoLinkType == ast.AutoLinkEmail {
+		return true
+	}
+	lower := strings.ToLower(label)
+	for _, prefix := range []string{"http://", "https://", "ftp://", "www."} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixLines prefixes every line of s with prefix, or blankPrefix for empty lines
+func prefixLines(s, prefix, blankPrefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = blankPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trimLineEnds removes trailing spaces and tabs from every line of s
+func trimLineEnds(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trimTrailingSpaces removes spaces and tabs from the end of buf
+func trimTrailingSpaces(buf *bytes.Buffer) {
+	end := buf.Len()
+	for end > 0 && (buf.Bytes()[end-1] == ' ' || buf.Bytes()[end-1] == '\t') {
+		end--
+	}
+	buf.Truncate(end)
+}
@@ -0,0 +1,107 @@
+package markdown
+
+import "testing"
+
+func TestMinifyMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "setext heading becomes ATX",
+			input:    "Title\n=====\n\nSub\n---",
+			expected: "# Title\n\n## Sub",
+		},
+		{
+			name:     "blank lines collapsed and trailing spaces removed",
+			input:    "First   \n\n\n\nSecond\t\n",
+			expected: "First\n\nSecond",
+		},
+		{
+			name:     "hard break written with a backslash",
+			input:    "line one  \nline two",
+			expected: "line one\\\nline two",
+		},
+		{
+			name:     "bullets normalized",
+			input:    "* one\n+ two\n    * nested",
+			expected: "- one\n\n* two\n  - nested",
+		},
+		{
+			name:     "ordered lists keep start and delimiter changes",
+			input:    "3. three\n4. four\n1) other",
+			expected: "3. three\n4. four\n\n1) other",
+		},
+		{
+			name:     "indented code becomes fenced",
+			input:    "Text\n\n    code line\n    second",
+			expected: "Text\n\n```\ncode line\nsecond\n```",
+		},
+		{
+			name:     "fence longer than backticks in content",
+			input:    "~~~\n```\n~~~",
+			expected: "````\n```\n````",
+		},
+		{
+			name:     "reference links inlined",
+			input:    "See [docs][d].\n\n[d]: https://example.com \"Docs\"",
+			expected: "See [docs](https://example.com \"Docs\").",
+		},
+		{
+			name:     "blockquote",
+			input:    ">quote\ncontinued\n>\n>> nested",
+			expected: "> quote\n> continued\n>\n> > nested",
+		},
+		{
+			name:     "table",
+			input:    "a|b\n:-|-:\n1|`x\\|y`",
+			expected: "| a | b |\n| :--- | ---: |\n| 1 | `x\\|y` |",
+		},
+		{
+			name:     "inline formatting",
+			input:    "__bold__ _it_ ~~del~~ ==mark== `` a`b `` <https://example.com> <b>raw</b>",
+			expected: "**bold** *it* ~~del~~ ==mark== ``a`b`` https://example.com <b>raw</b>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MinifyMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("MinifyMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMinifyMarkdownIdempotent(t *testing.T) {
+	inputs := []string{
+		"Title\n=====\n\nSome *text*  \nwith break   \n\n\n\n* one\n* two\n    * nested\n+ other list",
+		"1. a\n2. b\n\n   para in b\n3) c",
+		"> quote\n> more\n>\n> > nested\n\n- item\n\n  > quoted in item",
+		"```go\nfunc x() {}\n```\n\n    indented code\n\n~~~\n```\n~~~",
+		"| a | b |\n|:--|--:|\n| 1 | `x\\|y` |",
+		"Visit <https://x.com> or www.y.com or <mailto:a@b.c> and [ref][r]\n\n[r]: /url \"T\"",
+		"- [ ] todo\n- [x] done\n\n---\n\n<div>\nhtml\n</div>\n\n![alt *text*](<a b.png> \"t\")",
+		"- a\n- b\n* c\n\n1. x\n\n   ```\n   code\n   ```\n2. y",
+	}
+
+	for _, input := range inputs {
+		once := MinifyMarkdown(input)
+		twice := MinifyMarkdown(once)
+		if once != twice {
+			t.Errorf("MinifyMarkdown() is not idempotent\nInput:  %q\nOnce:   %q\nTwice:  %q", input, once, twice)
+		}
+
+		// Minifying must not change the plain text content
+		if StripMarkdown(once) != StripMarkdown(input) {
+			t.Errorf("MinifyMarkdown() changed the content\nInput:    %q\nExpected: %q\nGot:      %q", input, StripMarkdown(input), StripMarkdown(once))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultIndentUnit is the indentation ReindentLists uses per nesting level
+// when no unit is given
+const DefaultIndentUnit = "  "
+
+// listItemLine matches a bullet or numbered list item with its indentation
+var listItemLine = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])[ \t]+(.*)$`)
+
+// ReindentLists normalizes the indentation of list items in text so every
+// nesting level is indented by exactly one indentUnit (DefaultIndentUnit if empty).
+// Nesting depth is inferred from the relative indentation of consecutive items.
+// Indented continuation lines are aligned one level deeper than their item;
+// an unindented non-list line ends the list. Other lines are left unchanged.
+func ReindentLists(text string, indentUnit string) string {
+	if indentUnit == "" {
+		indentUnit = DefaultIndentUnit
+	}
+
+	lines := strings.Split(text, "\n")
+
+	// Indentation widths of the enclosing list levels, outermost first
+	var levels []int
+
+	for i, line := range lines {
+		match := listItemLine.FindStringSubmatch(line)
+		if match == nil {
+			trimmed := strings.TrimLeft(line, " \t")
+			switch {
+			case trimmed == "":
+				// Blank lines may separate items of a loose list
+			case trimmed == line:
+				levels = levels[:0]
+			case len(levels) > 0:
+				lines[i] = strings.Repeat(indentUnit, len(levels)) + trimmed
+			}
+			continue
+		}
+
+		width := indentWidth(match[1])
+		for len(levels) > 0 && levels[len(levels)-1] > width {
+			levels = levels[:len(levels)-1]
+		}
+		if len(levels) == 0 || levels[len(levels)-1] < width {
+			levels = append(levels, width)
+		}
+
+		depth := len(levels) - 1
+		lines[i] = strings.Repeat(indentUnit, depth) + match[2] + " " + match[3]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// indentWidth returns the visual width of leading whitespace,
+// counting a tab as four columns
+func indentWidth(indent string) int {
+	width := 0
+	for _, r := range indent {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,58 @@
+package markdown
+
+import "testing"
+
+func TestReindentLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		unit     string
+		expected string
+	}{
+		{
+			name:     "mixed indentation",
+			input:    "- One\n    - Nested with four\n\t- Nested with tab\n         - Deeper\n- Two",
+			unit:     "  ",
+			expected: "- One\n  - Nested with four\n  - Nested with tab\n    - Deeper\n- Two",
+		},
+		{
+			name:     "numbered items",
+			input:    "1. First\n   1. Sub\n2. Second",
+			unit:     "    ",
+			expected: "1. First\n    1. Sub\n2. Second",
+		},
+		{
+			name:     "dedent to an intermediate level",
+			input:    "- a\n      - b\n         - c\n      - d\n- e",
+			unit:     "",
+			expected: "- a\n  - b\n    - c\n  - d\n- e",
+		},
+		{
+			name:     "continuation lines follow their item",
+			input:    "- Item\n        continued text\n- Next",
+			unit:     "  ",
+			expected: "- Item\n  continued text\n- Next",
+		},
+		{
+			name:     "paragraph ends the list",
+			input:    "   - a\n      - b\nParagraph\n  - c",
+			unit:     "  ",
+			expected: "- a\n  - b\nParagraph\n- c",
+		},
+		{
+			name:     "plain text untouched",
+			input:    "Just text\n\n  indented text",
+			unit:     "  ",
+			expected: "Just text\n\n  indented text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReindentLists(tt.input, tt.unit)
+			if result != tt.expected {
+				t.Errorf("ReindentLists() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
@@ -2,8 +2,13 @@ package markdown
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+
+	"go-lib-ffi/buildinfo"
+	"go-lib-ffi/textutil"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
@@ -12,39 +17,225 @@ import (
 	"github.com/yuin/goldmark/text"
 )
 
-// Global goldmark instance with GitHub Flavored Markdown extensions
-var markdownConverter = goldmark.New(
-	goldmark.WithExtensions(extension.GFM),
+func init() {
+	buildinfo.Register("strip_markdown")
+}
+
+// Shared goldmark instance with GitHub Flavored Markdown extensions,
+// built on first use by markdownConverter
+var (
+	markdownOnce     sync.Once
+	markdownInstance goldmark.Markdown
+)
+
+// markdownConverter returns the shared goldmark instance, building it on
+// first use. It is safe for concurrent use.
+func markdownConverter() goldmark.Markdown {
+	markdownOnce.Do(func() {
+		markdownInstance = goldmark.New(
+			goldmark.WithExtensions(extension.GFM, highlight),
+		)
+	})
+	return markdownInstance
+}
+
+// HeadingStyle selects how StripMarkdownWithOptions renders headings
+type HeadingStyle string
+
+const (
+	// HeadingStyleNone renders headings as plain text (the default)
+	HeadingStyleNone HeadingStyle = "none"
+
+	// HeadingStyleKeepHashes keeps the ATX markers, e.g. "## Title"
+	HeadingStyleKeepHashes HeadingStyle = "keepHashes"
+
+	// HeadingStylePrefixLevel prefixes the level in brackets, e.g. "[H2] Title"
+	HeadingStylePrefixLevel HeadingStyle = "prefixLevel"
 )
 
+// DefaultThematicBreakMarker is a ThematicBreakMarker that keeps the rule
+// visible as a line of dashes
+const DefaultThematicBreakMarker = "----------"
+
+// DefaultListBullet is the ListBullet used when none is set
+const DefaultListBullet = "- "
+
+// StripOptions controls optional behavior of StripMarkdownWithOptions
+type StripOptions struct {
+	// TidyPunctuation removes spaces left in front of punctuation, collapses
+	// repeated spaces and trims spaces before line breaks.
+	// Code spans and code blocks are never modified.
+	TidyPunctuation bool `json:"tidy_punctuation"`
+
+	// HeadingStyle controls whether headings keep a level indicator.
+	// An empty value behaves like HeadingStyleNone.
+	HeadingStyle HeadingStyle `json:"heading_style"`
+
+	// AbbrExpansion appends the title of inline <abbr title="..."> HTML
+	// after its text, e.g. "HTML (HyperText Markup Language)"
+	AbbrExpansion bool `json:"abbr_expansion"`
+
+	// ListItemSeparator is written between the items of a list, e.g. "; "
+	// to join them on one line. An empty value behaves like "\n".
+	ListItemSeparator string `json:"list_item_separator"`
+
+	// ListBullet prefixes unordered list items.
+	// An empty value behaves like DefaultListBullet.
+	ListBullet string `json:"list_bullet"`
+
+	// DropListBullets writes unordered list items without a bullet.
+	// Ordered list items always keep their number.
+	DropListBullets bool `json:"drop_list_bullets"`
+
+	// KeepEmphasis keeps bold, italic and strikethrough markers around their
+	// text ("**bold**", "*italic*", "~~struck~~") instead of removing them.
+	// Underscore emphasis is written with asterisks.
+	KeepEmphasis bool `json:"keep_emphasis"`
+
+	// SeparateCodeSpans writes a space between code spans that would
+	// otherwise run together, e.g. "**`a`**`b`" gives "a b" instead of "ab"
+	SeparateCodeSpans bool `json:"separate_code_spans"`
+
+	// DropCodeBlocks omits the content of fenced and indented code blocks,
+	// and DropInlineCode the content of code spans, for prose-only output
+	DropCodeBlocks bool `json:"drop_code_blocks"`
+	DropInlineCode bool `json:"drop_inline_code"`
+
+	// MaxListItems keeps the first MaxListItems items of every list and
+	// replaces the rest with a line such as "… (+495 more)". Each list,
+	// nested lists included, is limited on its own. 0 keeps all items.
+	MaxListItems int `json:"max_list_items"`
+
+	// BlockquoteLineSeparator is written between the lines of a blockquote
+	// paragraph, e.g. "\n" to keep its line breaks. An empty value joins
+	// them like other soft line breaks.
+	BlockquoteLineSeparator string `json:"blockquote_line_separator"`
+
+	// SoftBreakAsNewline keeps soft line breaks (single newlines inside a
+	// paragraph) as newlines instead of turning them into spaces, for poetry
+	// or CJK text where an inserted space is wrong. Table cells still join
+	// their lines with spaces.
+	SoftBreakAsNewline bool `json:"soft_break_as_newline"`
+
+	// ThematicBreakMarker is written on its own line in place of thematic
+	// breaks ("---", "***"), e.g. DefaultThematicBreakMarker, so section
+	// separation survives. An empty value leaves only a blank line.
+	ThematicBreakMarker string `json:"thematic_break_marker"`
+
+	// StripInvisible removes zero-width characters, bidirectional marks and
+	// control characters from the source (see textutil.StripInvisible)
+	// so they cannot split words or inflate token counts
+	StripInvisible bool `json:"strip_invisible"`
+}
+
+// DefaultStripOptions returns the options used by StripMarkdown
+func DefaultStripOptions() StripOptions {
+	return StripOptions{
+		TidyPunctuation:   true,
+		ListItemSeparator: "\n",
+		ListBullet:        DefaultListBullet,
+	}
+}
+
 // StripMarkdown converts markdown text to plain text by removing all formatting
 // while preserving semantic content (link text, image alt text, code, etc.)
 // and basic structure (paragraph breaks, list bullets).
 func StripMarkdown(source string) string {
+	return StripMarkdownWithOptions(source, DefaultStripOptions())
+}
+
+// StripMarkdownWithOptions converts markdown text to plain text like StripMarkdown
+// with the behaviors selected in opts
+func StripMarkdownWithOptions(source string, opts StripOptions) string {
+	if opts.StripInvisible {
+		source = textutil.StripInvisible(source)
+	}
 	if source == "" {
 		return ""
 	}
 
 	// Parse the markdown into an AST
 	reader := text.NewReader([]byte(source))
-	doc := markdownConverter.Parser().Parse(reader)
+	doc := markdownConverter().Parser().Parse(reader)
+
+	// Number of items cut from each list by MaxListItems
+	cutItems := truncateLists(doc, opts.MaxListItems)
 
 	var buf bytes.Buffer
 	var listDepth int
 	var inListItem bool
 
+	// Next item number of each open list, innermost last.
+	// Every list node counts from its own start number.
+	var itemNumbers []int
+
+	itemSeparator := opts.ListItemSeparator
+	if itemSeparator == "" {
+		itemSeparator = "\n"
+	}
+	bullet := opts.ListBullet
+	switch {
+	case opts.DropListBullets:
+		bullet = ""
+	case bullet == "":
+		bullet = DefaultListBullet
+	}
+
+	softBreak := " "
+	if opts.SoftBreakAsNewline {
+		softBreak = "\n"
+	}
+	quoteSeparator := opts.BlockquoteLineSeparator
+	if quoteSeparator == "" {
+		quoteSeparator = softBreak
+	}
+	var quoteDepth int
+
+	// Start of the heading text being written
+	headingStart := 0
+
+	// Titles of the inline <abbr> elements currently open
+	var abbrTitles []string
+
+	// Start of the table cell being written, or -1 outside tables.
+	// Line breaks inside a cell become spaces so each row stays on one line.
+	cellStart := -1
+
+	// Byte ranges of buf holding code or indentation,
+	// which must survive whitespace tidying untouched
+	var protected [][2]int
+	var codeSpanStart, codeSpanEnd int
+
+	// Set after DropInlineCode drops a code span, so the spaces that stood
+	// on both sides of it collapse into one and a line does not start with one
+	var droppedCodeSpan bool
+	writeProtected := func(value []byte) {
+		start := buf.Len()
+		buf.Write(value)
+		protected = append(protected, [2]int{start, buf.Len()})
+	}
+
 	// Walk the AST and extract plain text
 	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
 		switch node := n.(type) {
 		case *ast.Text:
 			if entering {
-				buf.Write(node.Segment.Value([]byte(source)))
-				// Handle soft line breaks (convert to space)
-				if node.SoftLineBreak() {
-					buf.WriteString(" ")
+				value := node.Segment.Value([]byte(source))
+				if droppedCodeSpan && (buf.Len() == 0 || bytes.ContainsAny(buf.Bytes()[buf.Len()-1:], " \n")) {
+					value = bytes.TrimLeft(value, " ")
 				}
-				// Hard line breaks are preserved in the text
-				if node.HardLineBreak() {
+				droppedCodeSpan = false
+				buf.Write(value)
+				switch {
+				case cellStart >= 0 && (node.SoftLineBreak() || node.HardLineBreak()):
+					writeCellSpace(&buf, cellStart)
+				case quoteDepth > 0 && node.SoftLineBreak():
+					buf.WriteString(quoteSeparator)
+				case node.SoftLineBreak():
+					// Handle soft line breaks (convert to space by default)
+					buf.WriteString(softBreak)
+				case node.HardLineBreak():
+					// Hard line breaks are preserved in the text
 					buf.WriteString("\n")
 				}
 			}
@@ -55,19 +246,31 @@ func StripMarkdown(source string) string {
 			}
 
 		case *ast.CodeBlock, *ast.FencedCodeBlock:
-			if entering {
+			if entering && !opts.DropCodeBlocks {
 				// Extract code block content
 				lines := node.Lines()
 				for i := 0; i < lines.Len(); i++ {
 					line := lines.At(i)
-					buf.Write(line.Value([]byte(source)))
+					writeProtected(line.Value([]byte(source)))
 				}
 				buf.WriteString("\n")
 			}
 
 		case *ast.CodeSpan:
 			// Text content will be handled by child Text nodes
-			// Just pass through
+			if opts.DropInlineCode {
+				droppedCodeSpan = true
+				return ast.WalkSkipChildren, nil
+			}
+			if entering {
+				if opts.SeparateCodeSpans && codeSpanEnd > 0 && codeSpanEnd == buf.Len() {
+					buf.WriteString(" ")
+				}
+				codeSpanStart = buf.Len()
+			} else {
+				codeSpanEnd = buf.Len()
+				protected = append(protected, [2]int{codeSpanStart, codeSpanEnd})
+			}
 
 		case *ast.Image:
 			if entering {
@@ -83,8 +286,10 @@ func StripMarkdown(source string) string {
 		case *ast.List:
 			if entering {
 				listDepth++
+				itemNumbers = append(itemNumbers, node.Start)
 			} else {
 				listDepth--
+				itemNumbers = itemNumbers[:len(itemNumbers)-1]
 				if listDepth == 0 {
 					buf.WriteString("\n")
 				}
@@ -98,16 +303,27 @@ func StripMarkdown(source string) string {
 				if list, ok := parent.(*ast.List); ok {
 					indent := strings.Repeat("  ", listDepth-1)
 					if list.IsOrdered() {
-						buf.WriteString(indent)
-						buf.WriteString("1. ")
+						writeProtected([]byte(indent))
+						fmt.Fprintf(&buf, "%d. ", itemNumbers[len(itemNumbers)-1])
+						itemNumbers[len(itemNumbers)-1]++
 					} else {
-						buf.WriteString(indent)
-						buf.WriteString("- ")
+						writeProtected([]byte(indent))
+						buf.WriteString(bullet)
 					}
 				}
 			} else {
 				inListItem = false
-				buf.WriteString("\n")
+				switch {
+				case node.NextSibling() != nil:
+					buf.WriteString(itemSeparator)
+				case cutItems[node.Parent()] > 0:
+					buf.WriteString(itemSeparator)
+					writeProtected([]byte(strings.Repeat("  ", listDepth-1)))
+					buf.WriteString(textutil.MoreItems(cutItems[node.Parent()]))
+					buf.WriteString("\n")
+				default:
+					buf.WriteString("\n")
+				}
 			}
 
 		case *ast.Paragraph:
@@ -116,20 +332,37 @@ func StripMarkdown(source string) string {
 			}
 
 		case *ast.Heading:
-			if !entering {
+			if entering {
+				switch opts.HeadingStyle {
+				case HeadingStyleKeepHashes:
+					buf.WriteString(strings.Repeat("#", node.Level))
+					buf.WriteString(" ")
+				case HeadingStylePrefixLevel:
+					fmt.Fprintf(&buf, "[H%d] ", node.Level)
+				}
+				headingStart = buf.Len()
+			} else {
+				collapseHeadingSpace(&buf, headingStart, protected)
 				buf.WriteString("\n\n")
 			}
 
 		case *ast.Blockquote:
 			// Don't add extra newlines within blockquotes
 			// Just let the content flow naturally
-			if !entering {
+			if entering {
+				quoteDepth++
+			} else {
+				quoteDepth--
 				buf.WriteString("\n\n")
 			}
 
 		case *ast.ThematicBreak:
 			if entering {
 				buf.WriteString("\n\n")
+				if opts.ThematicBreakMarker != "" {
+					writeProtected([]byte(opts.ThematicBreakMarker))
+					buf.WriteString("\n\n")
+				}
 			}
 
 		case *ast.HTMLBlock:
@@ -137,7 +370,25 @@ func StripMarkdown(source string) string {
 			return ast.WalkSkipChildren, nil
 
 		case *ast.RawHTML:
-			// Skip inline HTML
+			// Skip inline HTML, remembering abbreviation titles to expand
+			// and turning <br> inside table cells into spaces
+			if !entering {
+				return ast.WalkSkipChildren, nil
+			}
+			tag := rawHTMLText(node, []byte(source))
+			if cellStart >= 0 && isLineBreakTag(tag) {
+				writeCellSpace(&buf, cellStart)
+			} else if opts.AbbrExpansion {
+				if title, ok := abbrOpenTagTitle(tag); ok {
+					abbrTitles = append(abbrTitles, title)
+				} else if isAbbrCloseTag(tag) && len(abbrTitles) > 0 {
+					title := abbrTitles[len(abbrTitles)-1]
+					abbrTitles = abbrTitles[:len(abbrTitles)-1]
+					if title != "" {
+						buf.WriteString(" (" + title + ")")
+					}
+				}
+			}
 			return ast.WalkSkipChildren, nil
 
 		case *extast.Table:
@@ -147,15 +398,30 @@ func StripMarkdown(source string) string {
 
 		case *extast.TableRow:
 			if !entering {
+				trimCellEnd(&buf, 0, protected)
 				buf.WriteString("\n")
 			}
 
 		case *extast.TableCell:
-			if !entering {
+			if entering {
+				cellStart = buf.Len()
+			} else {
+				trimCellEnd(&buf, cellStart, protected)
+				cellStart = -1
 				buf.WriteString(" ")
 			}
 
+		case *ast.Emphasis:
+			if opts.KeepEmphasis {
+				buf.WriteString(strings.Repeat("*", node.Level))
+			}
+
 		case *extast.Strikethrough:
+			if opts.KeepEmphasis {
+				buf.WriteString("~~")
+			}
+
+		case *Highlight:
 			// Pass through - children will be processed
 
 		case *ast.AutoLink:
@@ -175,6 +441,9 @@ func StripMarkdown(source string) string {
 
 	// Clean up excessive whitespace
 	result := buf.String()
+	if opts.TidyPunctuation {
+		result = tidyPunctuation(buf.Bytes(), protected)
+	}
 	result = strings.TrimSpace(result)
 
 	// Replace more than 2 consecutive newlines with exactly 2
@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -64,7 +65,7 @@ func TestStripMarkdown(t *testing.T) {
 		{
 			name:     "ordered list",
 			input:    "1. First\n2. Second\n3. Third",
-			expected: "1. First\n1. Second\n1. Third",
+			expected: "1. First\n2. Second\n3. Third",
 		},
 		{
 			name:     "nested list",
@@ -151,6 +152,103 @@ func TestStripMarkdown(t *testing.T) {
 	}
 }
 
+func TestStripMarkdownHeadingStyle(t *testing.T) {
+	input := "# One\n\n## Two\n\n### Three\n\nBody text"
+
+	tests := []struct {
+		name     string
+		style    HeadingStyle
+		expected string
+	}{
+		{
+			name:     "default",
+			style:    "",
+			expected: "One\n\nTwo\n\nThree\n\nBody text",
+		},
+		{
+			name:     "none",
+			style:    HeadingStyleNone,
+			expected: "One\n\nTwo\n\nThree\n\nBody text",
+		},
+		{
+			name:     "keep hashes",
+			style:    HeadingStyleKeepHashes,
+			expected: "# One\n\n## Two\n\n### Three\n\nBody text",
+		},
+		{
+			name:     "prefix level",
+			style:    HeadingStylePrefixLevel,
+			expected: "[H1] One\n\n[H2] Two\n\n[H3] Three\n\nBody text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.HeadingStyle = tt.style
+			result := StripMarkdownWithOptions(input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownHeadingCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     StripOptions
+		expected string
+	}{
+		{
+			name:     "code in heading",
+			input:    "## The `foo()` function",
+			opts:     DefaultStripOptions(),
+			expected: "The foo() function",
+		},
+		{
+			name:     "padded code span",
+			input:    "## The `  foo()  ` function\n\nBody with `  padded  ` code",
+			opts:     DefaultStripOptions(),
+			expected: "The foo() function\n\nBody with  padded  code",
+		},
+		{
+			name:     "tabs around code",
+			input:    "##\t`tab`\tcode",
+			opts:     DefaultStripOptions(),
+			expected: "tab code",
+		},
+		{
+			name:     "code, links and emphasis combined",
+			input:    "# Using [`ctx`](https://pkg.go.dev/context) with **`select`** and *timeouts* ##",
+			opts:     DefaultStripOptions(),
+			expected: "Using ctx with select and timeouts",
+		},
+		{
+			name:     "code keeps punctuation spacing",
+			input:    "## Call `f( a , b )` , then return",
+			opts:     DefaultStripOptions(),
+			expected: "Call f( a , b ), then return",
+		},
+		{
+			name:     "heading level kept",
+			input:    "### `Parse` ` ` and *`Render`*",
+			opts:     StripOptions{HeadingStyle: HeadingStyleKeepHashes, KeepEmphasis: true},
+			expected: "### Parse and *Render*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripMarkdownWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestStripMarkdownEdgeCases(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -222,3 +320,616 @@ Visit [this link](https://example.com) or see ![this image](img.jpg).
 		_ = StripMarkdown(input)
 	}
 }
+
+func TestStripMarkdownListOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		separator   string
+		bullet      string
+		dropBullets bool
+		expected    string
+	}{
+		{
+			name:      "newline separator",
+			input:     "- Apples\n- Pears\n- Plums",
+			separator: "\n",
+			bullet:    "- ",
+			expected:  "- Apples\n- Pears\n- Plums",
+		},
+		{
+			name:      "semicolon separator",
+			input:     "Fruit:\n\n- Apples\n- Pears\n- Plums\n\nDone",
+			separator: "; ",
+			bullet:    "- ",
+			expected:  "Fruit:\n\n- Apples; - Pears; - Plums\n\nDone",
+		},
+		{
+			name:        "semicolon separator without bullets",
+			input:       "- Apples\n- Pears\n- Plums",
+			separator:   "; ",
+			dropBullets: true,
+			expected:    "Apples; Pears; Plums",
+		},
+		{
+			name:      "custom bullet",
+			input:     "- Apples\n- Pears",
+			separator: "\n",
+			bullet:    "* ",
+			expected:  "* Apples\n* Pears",
+		},
+		{
+			name:        "ordered list keeps numbers without bullets",
+			input:       "1. First\n2. Second",
+			separator:   ", ",
+			dropBullets: true,
+			expected:    "1. First, 2. Second",
+		},
+		{
+			name:      "empty separator behaves like newline",
+			input:     "- Apples\n- Pears",
+			separator: "",
+			bullet:    "- ",
+			expected:  "- Apples\n- Pears",
+		},
+		{
+			name:      "empty bullet behaves like default",
+			input:     "- Apples\n- Pears",
+			separator: "\n",
+			bullet:    "",
+			expected:  "- Apples\n- Pears",
+		},
+		{
+			name:        "drop bullets wins over custom bullet",
+			input:       "- Apples\n- Pears",
+			separator:   "\n",
+			bullet:      "* ",
+			dropBullets: true,
+			expected:    "Apples\nPears",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.ListItemSeparator = tt.separator
+			opts.ListBullet = tt.bullet
+			opts.DropListBullets = tt.dropBullets
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownBlockquoteLineSeparator(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		expected  string
+	}{
+		{
+			name:      "joined with spaces by default",
+			input:     "> This is a quote\n> Multiple lines\n> And a third",
+			separator: "",
+			expected:  "This is a quote Multiple lines And a third",
+		},
+		{
+			name:      "line breaks kept",
+			input:     "> This is a quote\n> Multiple lines\n> And a third",
+			separator: "\n",
+			expected:  "This is a quote\nMultiple lines\nAnd a third",
+		},
+		{
+			name:      "paragraphs and nested quotes",
+			input:     "> Roses are red\n> Violets are blue\n>\n> > Sugar is sweet\n> > And so are you",
+			separator: "\n",
+			expected:  "Roses are red\nViolets are blue\n\nSugar is sweet\nAnd so are you",
+		},
+		{
+			name:      "lines outside quotes still joined",
+			input:     "Before the\nquote\n\n> Quoted\n> lines",
+			separator: "\n",
+			expected:  "Before the quote\n\nQuoted\nlines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.BlockquoteLineSeparator = tt.separator
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownSoftBreakAsNewline(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		newline  bool
+		expected string
+	}{
+		{
+			name:     "soft breaks become spaces by default",
+			input:    "Roses are red,\nviolets are blue.\n\nSecond stanza.",
+			expected: "Roses are red, violets are blue.\n\nSecond stanza.",
+		},
+		{
+			name:     "soft breaks kept",
+			input:    "Roses are red,\nviolets are blue.\n\nSecond stanza.",
+			newline:  true,
+			expected: "Roses are red,\nviolets are blue.\n\nSecond stanza.",
+		},
+		{
+			name:     "cjk text gets a space by default",
+			input:    "日本語の文章は\n改行されます。",
+			expected: "日本語の文章は 改行されます。",
+		},
+		{
+			name:     "cjk text keeps its line break",
+			input:    "日本語の文章は\n改行されます。",
+			newline:  true,
+			expected: "日本語の文章は\n改行されます。",
+		},
+		{
+			name:     "blockquote and list item lines",
+			input:    "> quoted\n> lines\n\n- item\n  continued",
+			newline:  true,
+			expected: "quoted\nlines\n\n- item\ncontinued",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.SoftBreakAsNewline = tt.newline
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownOrderedListNumbering(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "list keeps its start number",
+			input:    "3. Three\n4. Four\n5. Five",
+			expected: "3. Three\n4. Four\n5. Five",
+		},
+		{
+			name:     "two adjacent lists each start at one",
+			input:    "1. a\n2. b\n1) c\n2) d",
+			expected: "1. a\n2. b\n\n1. c\n2. d",
+		},
+		{
+			name:     "list resumed after a paragraph uses its own start",
+			input:    "1. First\n2. Second\n\nInterruption\n\n3. Third\n4. Fourth",
+			expected: "1. First\n2. Second\n\nInterruption\n\n3. Third\n4. Fourth",
+		},
+		{
+			name:     "nested list counts separately",
+			input:    "1. Outer\n   1. Inner one\n   2. Inner two\n2. Outer two",
+			expected: "1. Outer  1. Inner one\n  2. Inner two\n\n2. Outer two",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripMarkdown(tt.input)
+			if result != tt.expected {
+				t.Errorf("StripMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownKeepEmphasis(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		keep     bool
+		expected string
+	}{
+		{
+			name:     "bold kept",
+			input:    "This is **important** text",
+			keep:     true,
+			expected: "This is **important** text",
+		},
+		{
+			name:     "italic kept",
+			input:    "This is *subtle* text",
+			keep:     true,
+			expected: "This is *subtle* text",
+		},
+		{
+			name:     "underscore emphasis normalized",
+			input:    "__strong__ and _soft_",
+			keep:     true,
+			expected: "**strong** and *soft*",
+		},
+		{
+			name:     "strikethrough kept",
+			input:    "Price: ~~$20~~ $10",
+			keep:     true,
+			expected: "Price: ~~$20~~ $10",
+		},
+		{
+			name:     "nested emphasis kept",
+			input:    "***both*** and **bold with *italic***",
+			keep:     true,
+			expected: "***both*** and **bold with *italic***",
+		},
+		{
+			name:     "other formatting still stripped",
+			input:    "# Title\n\nA [**bold link**](https://example.com) and `code`",
+			keep:     true,
+			expected: "Title\n\nA **bold link** and code",
+		},
+		{
+			name:     "emphasis removed by default",
+			input:    "**bold**, *italic* and ~~struck~~",
+			keep:     false,
+			expected: "bold, italic and struck",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.KeepEmphasis = tt.keep
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownCodeSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		separate bool
+		expected string
+	}{
+		{
+			name:     "adjacent spans joined by default",
+			input:    "Run **`go`**`vet` now",
+			separate: false,
+			expected: "Run govet now",
+		},
+		{
+			name:     "adjacent spans separated",
+			input:    "Run **`go`**`vet` now",
+			separate: true,
+			expected: "Run go vet now",
+		},
+		{
+			name:     "spans split by empty inline HTML",
+			input:    "`a`<span></span>`b`",
+			separate: true,
+			expected: "a b",
+		},
+		{
+			name:     "spans already separated by text",
+			input:    "`a` and `b`",
+			separate: true,
+			expected: "a and b",
+		},
+		{
+			name:     "inner backtick run is one span",
+			input:    "`a``b`",
+			separate: true,
+			expected: "a``b",
+		},
+		{
+			name:     "backticks escaped with a longer delimiter",
+			input:    "Use `` `backtick` `` in code",
+			separate: true,
+			expected: "Use `backtick` in code",
+		},
+		{
+			name:     "nested backtick runs",
+			input:    "```a`` b```",
+			separate: true,
+			expected: "a`` b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.SeparateCodeSpans = tt.separate
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarkdownConverterConcurrentFirstUse(t *testing.T) {
+	const goroutines = 16
+	input := "# Title\n\nSome **bold** text and a [link](https://example.com)."
+	expected := StripMarkdownWithOptions(input, DefaultStripOptions())
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([]string, goroutines)
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if i%2 == 0 {
+				results[i] = StripMarkdown(input)
+			} else {
+				results[i] = MinifyMarkdown(input)
+			}
+		}()
+	}
+
+	// Discard the converter built above so the goroutines race for the first use
+	markdownOnce = sync.Once{}
+	markdownInstance = nil
+	close(start)
+	wg.Wait()
+
+	minified := MinifyMarkdown(input)
+	for i, result := range results {
+		want := expected
+		if i%2 != 0 {
+			want = minified
+		}
+		if result != want {
+			t.Errorf("concurrent first use failed\nGoroutine: %d\nExpected:  %q\nGot:       %q", i, want, result)
+		}
+	}
+}
+
+func TestStripMarkdownMaxListItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxItems int
+		expected string
+	}{
+		{
+			name:     "list under the limit",
+			input:    "- a\n- b\n- c",
+			maxItems: 3,
+			expected: "- a\n- b\n- c",
+		},
+		{
+			name:     "list over the limit",
+			input:    "- a\n- b\n- c\n- d\n- e",
+			maxItems: 2,
+			expected: "- a\n- b\n… (+3 more)",
+		},
+		{
+			name:     "ordered list",
+			input:    "1. a\n2. b\n3. c",
+			maxItems: 1,
+			expected: "1. a\n… (+2 more)",
+		},
+		{
+			name:     "nested lists limited per level",
+			input:    "- a\n  - a1\n  - a2\n  - a3\n- b\n- c",
+			maxItems: 2,
+			expected: "- a  - a1\n  - a2\n  … (+1 more)\n\n- b\n… (+1 more)",
+		},
+		{
+			name:     "no limit",
+			input:    "- a\n- b\n- c",
+			maxItems: 0,
+			expected: "- a\n- b\n- c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.MaxListItems = tt.maxItems
+			result := StripMarkdownWithOptions(tt.input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func FuzzStripMarkdown(f *testing.F) {
+	seeds := []string{
+		"",
+		"# Title\n\nSome **bold** and *italic* text.",
+		"- a\n  - b\n    1. c\n\n> quote\n\n```go\nx := 1\n```",
+		"| a | b |\n|---|:-:|\n| `x\\|y` | <br> |",
+		"<abbr title=\"x\">X</abbr> [link](<url> \"title\") ![img](src)",
+		"3. start\n4. next\n\n---\n\n==mark== ~~del~~",
+		"`` ` `` \\\n[ref]: https://example.com",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	opts := StripOptions{
+		HeadingStyle:      HeadingStylePrefixLevel,
+		AbbrExpansion:     true,
+		ListItemSeparator: "; ",
+		KeepEmphasis:      true,
+		SeparateCodeSpans: true,
+		MaxListItems:      1,
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		StripMarkdown(input)
+		StripMarkdownWithOptions(input, opts)
+		MinifyMarkdown(input)
+	})
+}
+
+func TestStripMarkdownThematicBreakMarker(t *testing.T) {
+	input := "Intro\n\n---\n\nNext section\n\n* * *\nLast  part ."
+
+	tests := []struct {
+		name     string
+		marker   string
+		expected string
+	}{
+		{
+			name:     "blank line by default",
+			expected: "Intro\n\nNext section\n\nLast part.",
+		},
+		{
+			name:     "default marker",
+			marker:   DefaultThematicBreakMarker,
+			expected: "Intro\n\n----------\n\nNext section\n\n----------\n\nLast part.",
+		},
+		{
+			name:     "custom marker kept verbatim",
+			marker:   "*  *  *",
+			expected: "Intro\n\n*  *  *\n\nNext section\n\n*  *  *\n\nLast part.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.ThematicBreakMarker = tt.marker
+			result := StripMarkdownWithOptions(input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownDropCode(t *testing.T) {
+	input := "Install it with `go get` first.\n\n```go\nfmt.Println(\"hi\")\n```\n\nThen run:\n\n    go run .\n\nDone."
+
+	tests := []struct {
+		name       string
+		dropBlocks bool
+		dropInline bool
+		expected   string
+	}{
+		{
+			name:     "code kept by default",
+			expected: "Install it with go get first.\n\nfmt.Println(\"hi\")\n\nThen run:\n\ngo run .\n\nDone.",
+		},
+		{
+			name:       "code blocks dropped",
+			dropBlocks: true,
+			expected:   "Install it with go get first.\n\nThen run:\n\nDone.",
+		},
+		{
+			name:       "inline code dropped",
+			dropInline: true,
+			expected:   "Install it with first.\n\nfmt.Println(\"hi\")\n\nThen run:\n\ngo run .\n\nDone.",
+		},
+		{
+			name:       "all code dropped",
+			dropBlocks: true,
+			dropInline: true,
+			expected:   "Install it with first.\n\nThen run:\n\nDone.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.DropCodeBlocks = tt.dropBlocks
+			opts.DropInlineCode = tt.dropInline
+			result := StripMarkdownWithOptions(input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownDropInlineCodeSpacing(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "span between words",
+			input:    "Use `foo` here.",
+			expected: "Use here.",
+		},
+		{
+			name:     "adjacent spans",
+			input:    "Run `a` `b` now.",
+			expected: "Run now.",
+		},
+		{
+			name:     "span at line start",
+			input:    "`foo` is set.",
+			expected: "is set.",
+		},
+		{
+			name:     "span at paragraph start",
+			input:    "First.\n\n`foo` is set.",
+			expected: "First.\n\nis set.",
+		},
+		{
+			name:     "span inside a word",
+			input:    "pre`foo`post and more",
+			expected: "prepost and more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripMarkdownWithOptions(tt.input, StripOptions{DropInlineCode: true})
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownStripInvisible(t *testing.T) {
+	input := "# Tok\u200ben counts\n\nSome\u200c text with a \x1b control\tand tab.\n\n`co\u200bde`"
+
+	tests := []struct {
+		name     string
+		strip    bool
+		expected string
+	}{
+		{
+			name:     "kept by default",
+			expected: "Tok\u200ben counts\n\nSome\u200c text with a \x1b control\tand tab.\n\nco\u200bde",
+		},
+		{
+			name:     "removed",
+			strip:    true,
+			expected: "Token counts\n\nSome text with a control\tand tab.\n\ncode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultStripOptions()
+			opts.StripInvisible = tt.strip
+			result := StripMarkdownWithOptions(input, opts)
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", input, tt.expected, result)
+			}
+		})
+	}
+}
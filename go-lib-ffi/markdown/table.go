@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// lineBreakTag matches an inline <br> tag in any of its spellings
+var lineBreakTag = regexp.MustCompile(`(?i)^<br\s*/?>$`)
+
+// isLineBreakTag reports whether tag is an inline <br> tag
+func isLineBreakTag(tag string) bool {
+	return lineBreakTag.MatchString(strings.TrimSpace(tag))
+}
+
+// writeCellSpace writes a single space separating words inside a table cell
+// that started at cellStart. Nothing is written at the start of the cell or
+// after existing whitespace, so line breaks never pile up into runs of spaces.
+func writeCellSpace(buf *bytes.Buffer, cellStart int) {
+	content := buf.Bytes()[cellStart:]
+	if len(content) == 0 || isSpaceByte(content[len(content)-1]) {
+		return
+	}
+	buf.WriteByte(' ')
+}
+
+// trimCellEnd removes trailing whitespace written after cellStart, such as
+// the space following the last cell of a row, and clamps protected ranges to the shortened buffer
+func trimCellEnd(buf *bytes.Buffer, cellStart int, protected [][2]int) {
+	end := buf.Len()
+	for end > cellStart && isSpaceByte(buf.Bytes()[end-1]) {
+		end--
+	}
+	buf.Truncate(end)
+
+	for i := range protected {
+		if protected[i][1] > end {
+			protected[i][1] = max(end, protected[i][0])
+		}
+	}
+}
+
+// isSpaceByte reports whether b is an ASCII whitespace byte
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
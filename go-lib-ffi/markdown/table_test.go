@@ -0,0 +1,61 @@
+package markdown
+
+import "testing"
+
+func TestStripMarkdownTableCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "br inside cell becomes a space",
+			input:    "| Name | Notes |\n|---|---|\n| one<br>two | three<br/>four |",
+			expected: "Name Notes one two three four",
+		},
+		{
+			name:     "leading and trailing breaks dropped",
+			input:    "| A | B |\n|---|---|\n| <br>x<br /> | y |",
+			expected: "A B x y",
+		},
+		{
+			name:     "inline formatting inside cells",
+			input:    "| Item | Status |\n|---|---|\n| **bold**<br>*note* | `code` |\n| [link](https://example.com) | ~~old~~ new |",
+			expected: "Item Status bold note code\nlink old new",
+		},
+		{
+			name:     "hard break outside tables preserved",
+			input:    "line one\\\nline two",
+			expected: "line one\nline two",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Disable tidying so spacing comes from the table handling alone
+			result := StripMarkdownWithOptions(tt.input, StripOptions{})
+			if result != tt.expected {
+				t.Errorf("StripMarkdownWithOptions() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsLineBreakTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"<br>", true},
+		{"<BR/>", true},
+		{"<br />", true},
+		{"<b>", false},
+		{"<brand>", false},
+	}
+
+	for _, tt := range tests {
+		if result := isLineBreakTag(tt.input); result != tt.expected {
+			t.Errorf("isLineBreakTag() failed\nInput:    %q\nExpected: %v\nGot:      %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+func init() {
+	buildinfo.Register("markdown_tables")
+}
+
+// ExtractTablesFromMarkdown returns the GFM tables of a markdown document as
+// rows of plain-text cells, header row first, in document order, ready to be
+// written out as TSV or CSV. Formatting is stripped from the cells like
+// StripMarkdown does. Rows are as wide as the header row: missing cells are
+// empty and extra cells are dropped, as GFM renders them.
+func ExtractTablesFromMarkdown(source string) [][][]string {
+	tables := [][][]string{}
+	if strings.TrimSpace(source) == "" {
+		return tables
+	}
+
+	src := []byte(source)
+	doc := markdownConverter().Parser().Parse(text.NewReader(src))
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		table, ok := n.(*extast.Table)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		var rows [][]string
+		for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+			cells := []string{}
+			for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+				cells = append(cells, cellText(cell, src))
+			}
+			rows = append(rows, cells)
+		}
+		tables = append(tables, rows)
+		return ast.WalkSkipChildren, nil
+	})
+
+	return tables
+}
+
+// cellText returns the plain text of a table cell with whitespace collapsed.
+// Inline HTML is dropped, autolinks keep their URL and backslash escapes
+// outside code spans are resolved.
+func cellText(cell ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	_ = ast.Walk(cell, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node := n.(type) {
+		case *ast.Text:
+			// Backslash escapes such as "\|" stay in the source text
+			buf.Write(util.UnescapePunctuations(node.Segment.Value(source)))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(node.Value)
+		case *ast.CodeSpan:
+			// Code keeps its backslashes
+			for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+				if t, ok := child.(*ast.Text); ok {
+					buf.Write(t.Segment.Value(source))
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			buf.Write(node.URL(source))
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTablesFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [][][]string
+	}{
+		{
+			name: "two columns with header",
+			input: "| Name | Age |\n" +
+				"| ---- | --: |\n" +
+				"| Alice | 30 |\n" +
+				"| Bob | 25 |",
+			expected: [][][]string{{
+				{"Name", "Age"},
+				{"Alice", "30"},
+				{"Bob", "25"},
+			}},
+		},
+		{
+			name: "ragged rows",
+			input: "| Name | Age |\n" +
+				"| --- | --- |\n" +
+				"| Alice |\n" +
+				"| Bob | 25 | extra |",
+			expected: [][][]string{{
+				{"Name", "Age"},
+				{"Alice", ""},
+				{"Bob", "25"},
+			}},
+		},
+		{
+			name: "formatting stripped from cells",
+			input: "| Tool | Link |\n" +
+				"| --- | --- |\n" +
+				"| **Go** `1.22` | [site](https://go.dev) |\n" +
+				"| a \\| b | <https://example.com> |\n" +
+				"| `C:\\*` | 2\\* |",
+			expected: [][][]string{{
+				{"Tool", "Link"},
+				{"Go 1.22", "site"},
+				{"a | b", "https://example.com"},
+				{"C:\\*", "2*"},
+			}},
+		},
+		{
+			name: "multiple tables",
+			input: "| A |\n| - |\n| 1 |\n\nText between.\n\n" +
+				"| B |\n| - |\n| 2 |",
+			expected: [][][]string{
+				{{"A"}, {"1"}},
+				{{"B"}, {"2"}},
+			},
+		},
+		{
+			name:     "no tables",
+			input:    "# Title\n\nJust text.",
+			expected: [][][]string{},
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: [][][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractTablesFromMarkdown(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExtractTablesFromMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
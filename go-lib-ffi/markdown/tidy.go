@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// spaceBeforePunctuation matches whitespace left in front of punctuation
+	// that ends a clause, e.g. "end ." once an inline element was removed.
+	// The punctuation must be followed by whitespace or the end of the text
+	// so tokens like ".NET" are left alone.
+	spaceBeforePunctuation = regexp.MustCompile(`[ \t]+([,.;:!?]+)(\s|$)`)
+
+	// repeatedSpaces matches runs of two or more spaces
+	repeatedSpaces = regexp.MustCompile(` {2,}`)
+
+	// trailingSpaces matches spaces and tabs at the end of a line
+	trailingSpaces = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// tidyPunctuation normalizes spacing in stripped text.
+// The protected byte ranges (code and list indentation) are copied verbatim;
+// they must be sorted and must not overlap.
+func tidyPunctuation(text []byte, protected [][2]int) string {
+	var sb strings.Builder
+	sb.Grow(len(text))
+
+	pos := 0
+	for _, r := range protected {
+		if r[0] < pos {
+			continue
+		}
+		sb.WriteString(tidySegment(string(text[pos:r[0]])))
+		sb.Write(text[r[0]:r[1]])
+		pos = r[1]
+	}
+	sb.WriteString(tidySegment(string(text[pos:])))
+
+	return sb.String()
+}
+
+// tidySegment normalizes spacing in a run of prose
+func tidySegment(segment string) string {
+	segment = spaceBeforePunctuation.ReplaceAllString(segment, "$1$2")
+	segment = repeatedSpaces.ReplaceAllString(segment, " ")
+	return trailingSpaces.ReplaceAllString(segment, "\n")
+}
@@ -0,0 +1,73 @@
+package markdown
+
+import "testing"
+
+func TestStripMarkdownTidyPunctuation(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		tidy   string
+		untidy string
+	}{
+		{
+			name:   "space before comma",
+			input:  "word , word",
+			tidy:   "word, word",
+			untidy: "word , word",
+		},
+		{
+			name:   "space before period after removed link",
+			input:  "See the [docs](https://example.com) .",
+			tidy:   "See the docs.",
+			untidy: "See the docs .",
+		},
+		{
+			name:   "double space collapse",
+			input:  "too  many   spaces",
+			tidy:   "too many spaces",
+			untidy: "too  many   spaces",
+		},
+		{
+			name:   "dotted names are kept",
+			input:  "Built on .NET and Node .js",
+			tidy:   "Built on .NET and Node .js",
+			untidy: "Built on .NET and Node .js",
+		},
+		{
+			name:   "code spans are not modified",
+			input:  "Run `a  ,  b` now",
+			tidy:   "Run a  ,  b now",
+			untidy: "Run a  ,  b now",
+		},
+		{
+			name:   "spaces before hard line break",
+			input:  "first line\\\nsecond line",
+			tidy:   "first line\nsecond line",
+			untidy: "first line\nsecond line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripMarkdownWithOptions(tt.input, StripOptions{TidyPunctuation: true})
+			if result != tt.tidy {
+				t.Errorf("StripMarkdownWithOptions(tidy) failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.tidy, result)
+			}
+
+			result = StripMarkdownWithOptions(tt.input, StripOptions{})
+			if result != tt.untidy {
+				t.Errorf("StripMarkdownWithOptions(untidy) failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.untidy, result)
+			}
+		})
+	}
+}
+
+func TestStripMarkdownDefaultsToTidy(t *testing.T) {
+	input := "Hello [world](https://example.com) !"
+	if StripMarkdown(input) != StripMarkdownWithOptions(input, DefaultStripOptions()) {
+		t.Errorf("StripMarkdown() should use DefaultStripOptions()")
+	}
+	if got := StripMarkdown(input); got != "Hello world!" {
+		t.Errorf("StripMarkdown() = %q, want %q", got, "Hello world!")
+	}
+}
@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenHeuristic selects how EstimateTokensWith approximates a token count
+type TokenHeuristic int
+
+const (
+	// TokenHeuristicChars assumes roughly four characters per token.
+	// For English prose this lands within about 20% of BPE tokenizers
+	// such as cl100k; it underestimates code and CJK text, where tokens
+	// are closer to one or two characters each.
+	TokenHeuristicChars TokenHeuristic = iota
+
+	// TokenHeuristicWords assumes roughly three words per four tokens.
+	// It is steadier than the character count on prose with long words,
+	// but badly underestimates languages written without spaces.
+	TokenHeuristicWords
+)
+
+// EstimateTokens returns an approximate LLM token count for markdown source.
+// The markdown is stripped first so formatting syntax is not counted.
+// It uses TokenHeuristicChars; see EstimateTokensWith to choose another heuristic.
+func EstimateTokens(source string) int {
+	return EstimateTokensWith(source, TokenHeuristicChars)
+}
+
+// EstimateTokensWith returns an approximate token count for markdown source
+// using the given heuristic. Estimates are rounded up, so any non-empty
+// text counts as at least one token.
+func EstimateTokensWith(source string, heuristic TokenHeuristic) int {
+	plain := StripMarkdown(source)
+	if plain == "" {
+		return 0
+	}
+
+	switch heuristic {
+	case TokenHeuristicWords:
+		words := len(strings.Fields(plain))
+		return (words*4 + 2) / 3
+	default:
+		chars := utf8.RuneCountInString(plain)
+		return (chars + 3) / 4
+	}
+}
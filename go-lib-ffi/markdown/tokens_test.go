@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		heuristic TokenHeuristic
+		expected  int
+	}{
+		{
+			name:      "empty",
+			input:     "",
+			heuristic: TokenHeuristicChars,
+			expected:  0,
+		},
+		{
+			name:      "short text rounds up",
+			input:     "Hi",
+			heuristic: TokenHeuristicChars,
+			expected:  1,
+		},
+		{
+			name:      "formatting is not counted",
+			input:     "**Hello** [world](https://example.com/a/very/long/url)",
+			heuristic: TokenHeuristicChars,
+			expected:  3, // "Hello world" is 11 characters
+		},
+		{
+			name:      "words heuristic",
+			input:     "one two three four five six",
+			heuristic: TokenHeuristicWords,
+			expected:  8,
+		},
+		{
+			name:      "multibyte counts runes not bytes",
+			input:     "日本語のテキスト",
+			heuristic: TokenHeuristicChars,
+			expected:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EstimateTokensWith(tt.input, tt.heuristic)
+			if result != tt.expected {
+				t.Errorf("EstimateTokensWith() failed\nInput:    %q\nExpected: %d\nGot:      %d", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestEstimateTokensLongDocument(t *testing.T) {
+	short := "# Title\n\nA short paragraph about the topic at hand."
+	long := strings.Repeat(short+"\n\n", 50)
+
+	shortChars, longChars := EstimateTokens(short), EstimateTokens(long)
+	if longChars < 45*shortChars || longChars > 55*shortChars {
+		t.Errorf("EstimateTokens() should scale with length: short=%d long=%d", shortChars, longChars)
+	}
+
+	// Both heuristics should agree within a factor of two on ordinary prose
+	chars := EstimateTokensWith(long, TokenHeuristicChars)
+	words := EstimateTokensWith(long, TokenHeuristicWords)
+	if chars > 2*words || words > 2*chars {
+		t.Errorf("heuristics diverge on prose: chars=%d words=%d", chars, words)
+	}
+}
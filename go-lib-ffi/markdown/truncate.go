@@ -0,0 +1,97 @@
+package markdown
+
+import (
+	"strings"
+
+	"go-lib-ffi/textutil"
+)
+
+// TruncationMarker is appended to markdown that was cut short
+const TruncationMarker = "\n\n[... truncated]"
+
+// TruncateMarkdown shortens markdown so the result fits in maxBytes.
+// It behaves like textutil.TruncateBytes but additionally closes a fenced
+// code block left open by the cut, so the output never ends inside a fence.
+func TruncateMarkdown(source string, maxBytes int, marker string) (string, textutil.TruncateReport) {
+	report := textutil.TruncateReport{OriginalBytes: len(source)}
+	if maxBytes <= 0 || len(source) <= maxBytes {
+		return source, report
+	}
+
+	budget := maxBytes - len(marker)
+	if budget < 0 {
+		budget = maxBytes
+		marker = ""
+	}
+
+	cut := textutil.RuneBoundary(source, budget)
+	closer := fenceCloser(source[:cut])
+
+	// Make room for the closing fence, shrinking until the kept prefix
+	// and its closer fit in the budget together
+	for closer != "" && cut+len(closer) > budget && cut > 0 {
+		cut = textutil.RuneBoundary(source, budget-len(closer))
+		closer = fenceCloser(source[:cut])
+	}
+
+	report.Truncated = true
+	report.CutBytes = len(source) - cut
+
+	return source[:cut] + closer + marker, report
+}
+
+// fenceCloser returns the text needed to close a fenced code block that is
+// still open at the end of content, or an empty string if none is open
+func fenceCloser(content string) string {
+	var open string
+
+	for _, line := range strings.Split(content, "\n") {
+		fence := fenceMarker(line)
+		if fence == "" {
+			continue
+		}
+
+		if open == "" {
+			open = fence
+			continue
+		}
+
+		// A closing fence uses the same character, is at least as long
+		// as the opener, and carries no info string
+		if fence[0] == open[0] && len(fence) >= len(open) &&
+			strings.TrimSpace(line) == fence {
+			open = ""
+		}
+	}
+
+	if open == "" {
+		return ""
+	}
+	if strings.HasSuffix(content, "\n") {
+		return open
+	}
+	return "\n" + open
+}
+
+// fenceMarker returns the run of backticks or tildes opening line
+// if it is a code fence (at most three spaces of indentation)
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return ""
+	}
+
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return ""
+	}
+
+	n := 0
+	for n < len(trimmed) && trimmed[n] == char {
+		n++
+	}
+	if n < 3 {
+		return ""
+	}
+	return trimmed[:n]
+}
@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxBytes int
+		marker   string
+		expected string
+	}{
+		{
+			name:     "under limit",
+			input:    "# Title\n\nText",
+			maxBytes: 100,
+			marker:   "[cut]",
+			expected: "# Title\n\nText",
+		},
+		{
+			name:     "plain text cut",
+			input:    "Paragraph one.\n\nParagraph two.",
+			maxBytes: 14,
+			marker:   "",
+			expected: "Paragraph one.",
+		},
+		{
+			name:     "closes open fence",
+			input:    "Intro\n\n```go\nfunc main() {\n\tprintln(1)\n}\n```",
+			maxBytes: 30,
+			marker:   "",
+			expected: "Intro\n\n```go\nfunc main() {\n```",
+		},
+		{
+			name:     "closed fence left alone",
+			input:    "```\ncode\n```\n\nAfter the fence there is more text",
+			maxBytes: 20,
+			marker:   "",
+			expected: "```\ncode\n```\n\nAfter ",
+		},
+		{
+			name:     "tilde fence with marker",
+			input:    "~~~~\nline one\nline two\n~~~~",
+			maxBytes: 25,
+			marker:   "…",
+			expected: "~~~~\nline one\nlin\n~~~~…",
+		},
+		{
+			name:     "multibyte inside fence",
+			input:    "```\n世界世界世界\n```",
+			maxBytes: 14,
+			marker:   "",
+			expected: "```\n世界\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, report := TruncateMarkdown(tt.input, tt.maxBytes, tt.marker)
+			if result != tt.expected {
+				t.Errorf("TruncateMarkdown() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("TruncateMarkdown() produced invalid UTF-8: %q", result)
+			}
+			if len(result) > tt.maxBytes {
+				t.Errorf("TruncateMarkdown() output exceeds limit: %d > %d", len(result), tt.maxBytes)
+			}
+			if report.Truncated != (result != tt.input) {
+				t.Errorf("TruncateMarkdown() report mismatch: %+v", report)
+			}
+		})
+	}
+}
+
+func TestTruncateMarkdownNeverLeavesFenceOpen(t *testing.T) {
+	input := "Text\n\n```\n" + strings.Repeat("code line\n", 20) + "```\n\nMore text"
+	for max := 1; max < len(input); max++ {
+		result, _ := TruncateMarkdown(input, max, TruncationMarker)
+		if fenceCloser(result) != "" {
+			t.Fatalf("TruncateMarkdown(max=%d) left a fence open: %q", max, result)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	registerEngine("bing", func(htmlStr string, maxResults int, opts SearchOptions) []SearchResult {
+		return parseBingResults(htmlStr, maxResults, opts.fieldLimit())
+	})
+}
+
+// ParseBingResults parses Bing search results HTML (li.b_algo results).
+// Extracts the h2 link title and target (unwrapping "/ck/a" redirects), the
+// caption paragraph as the snippet and the <cite> display URL.
+// Handles up to maxResults (default 20) results, with fields capped at
+// DefaultMaxFieldBytes
+func ParseBingResults(htmlStr string, maxResults int) []SearchResult {
+	return parseBingResults(htmlStr, maxResults, DefaultMaxFieldBytes)
+}
+
+// parseBingResults parses results like ParseBingResults with fields
+// capped at maxFieldBytes (see extractBoundedText)
+func parseBingResults(htmlStr string, maxResults, maxFieldBytes int) []SearchResult {
+	return collectResults(htmlStr, maxResults, maxFieldBytes, func(n *html.Node) bool {
+		return n.Data == "li" && hasClass(n, "b_algo")
+	}, parseBingResult)
+}
+
+// parseBingResult extracts data from a single li.b_algo result
+func parseBingResult(li *html.Node, maxFieldBytes int) SearchResult {
+	var result SearchResult
+
+	if h2 := findFirst(li, func(n *html.Node) bool { return n.Data == "h2" }); h2 != nil {
+		if a := findFirst(h2, func(n *html.Node) bool { return n.Data == "a" }); a != nil {
+			result.Title = extractBoundedText(a, maxFieldBytes)
+			result.Link = UnwrapRedirect(getAttr(a, "href"))
+		}
+	}
+
+	caption := findFirst(li, func(n *html.Node) bool { return hasClass(n, "b_caption") })
+	if caption == nil {
+		caption = li
+	}
+	snippet := findFirst(caption, func(n *html.Node) bool {
+		return n.Data == "p" || strings.HasPrefix(getAttr(n, "class"), "b_lineclamp")
+	})
+	if snippet != nil {
+		result.Snippet = extractBoundedText(snippet, maxFieldBytes)
+	}
+
+	if cite := findFirst(li, func(n *html.Node) bool { return n.Data == "cite" }); cite != nil {
+		result.DisplayURL = extractBoundedText(cite, maxFieldBytes)
+	}
+
+	return result
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseBingResults(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/bing_results.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	expected := []SearchResult{
+		{
+			Title:    "Tutorial: Get started with Go",
+			Link:     "https://go.dev/doc/tutorial/getting-started",
+			Snippet:  "In this tutorial, you'll get a brief introduction to Go programming.",
+			Position: 1,
+		},
+		{
+			Title:    "Go by Example",
+			Link:     "https://gobyexample.com/",
+			Snippet:  "Go by Example is a hands-on introduction to Go using annotated example programs.",
+			Position: 2,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		maxResults int
+		expected   []SearchResult
+	}{
+		{
+			name:       "results fixture",
+			input:      string(fixture),
+			maxResults: 10,
+			expected:   expected,
+		},
+		{
+			name:       "limit results",
+			input:      string(fixture),
+			maxResults: 1,
+			expected:   expected[:1],
+		},
+		{
+			name:       "empty input",
+			input:      "",
+			maxResults: 5,
+			expected:   []SearchResult{},
+		},
+		{
+			name: "redirect link and cite",
+			input: `<ol><li class="b_algo"><h2><a href="https://www.bing.com/ck/a?!&amp;&amp;p=abc&amp;u=a1aHR0cHM6Ly9leGFtcGxlLmNvbS9iaW5n&amp;ntb=1">Wrapped</a></h2>` +
+				`<div class="b_attribution"><cite>https://example.com › bing</cite></div><p class="b_lineclamp2">Clamped snippet</p></li></ol>`,
+			maxResults: 5,
+			expected: []SearchResult{
+				{Title: "Wrapped", Link: "https://example.com/bing", Snippet: "Clamped snippet", DisplayURL: "https://example.com › bing", Position: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseBingResults(tt.input, tt.maxResults)
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("ParseBingResults() failed\nExpected: %+v\nGot:      %+v", tt.expected, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	buildinfo.Register("detect_search_page")
+}
+
+// resultSignature identifies a search engine by the element wrapping each
+// of its results
+type resultSignature struct {
+	engine string
+	tag    string
+	class  string
+}
+
+// resultSignatures lists the known result containers, checked in order
+var resultSignatures = []resultSignature{
+	{engine: "duckduckgo", tag: "div", class: "result"},
+	{engine: "google", tag: "div", class: "g"},
+	{engine: "bing", tag: "li", class: "b_algo"},
+}
+
+// IsSearchResultsPage reports whether htmlStr looks like a search engine
+// results page (see DetectSearchEngine)
+func IsSearchResultsPage(htmlStr string) bool {
+	return DetectSearchEngine(htmlStr) != ""
+}
+
+// DetectSearchEngine returns the engine whose result containers appear in
+// htmlStr: "duckduckgo" (div.result), "google" (div.g) or "bing"
+// (li.b_algo). Returns "" for other pages. When several signatures
+// match, the first container in document order decides.
+func DetectSearchEngine(htmlStr string) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return ""
+	}
+
+	var engine string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if engine != "" {
+			return
+		}
+		if n.Type == html.ElementNode {
+			for _, sig := range resultSignatures {
+				if n.Data == sig.tag && hasClass(n, sig.class) {
+					engine = sig.engine
+					return
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return engine
+}
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectSearchEngine(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected string
+	}{
+		{
+			name:     "duckduckgo",
+			fixture:  "testdata/duckduckgo_results.html",
+			expected: "duckduckgo",
+		},
+		{
+			name:     "google",
+			fixture:  "testdata/google_results.html",
+			expected: "google",
+		},
+		{
+			name:     "bing",
+			fixture:  "testdata/bing_results.html",
+			expected: "bing",
+		},
+		{
+			name:     "reddit listing",
+			fixture:  "testdata/reddit_listing.html",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatalf("failed to read fixture: %v", err)
+			}
+
+			result := DetectSearchEngine(string(input))
+			if result != tt.expected {
+				t.Errorf("DetectSearchEngine() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.fixture, tt.expected, result)
+			}
+			if detected := IsSearchResultsPage(string(input)); detected != (tt.expected != "") {
+				t.Errorf("IsSearchResultsPage() failed\nInput:    %s\nExpected: %v\nGot:      %v", tt.fixture, tt.expected != "", detected)
+			}
+		})
+	}
+}
+
+func TestIsSearchResultsPageArticle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name: "article",
+			input: `<html><head><title>Getting started with Go</title></head><body><article>` +
+				`<h1>Getting started</h1><p class="results-summary">Go compiles quickly.</p>` +
+				`<div class="note">Install Go first.</div></article></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if IsSearchResultsPage(tt.input) {
+				t.Errorf("IsSearchResultsPage() failed\nInput:    %s\nExpected: false\nGot:      true", tt.input)
+			}
+		})
+	}
+}
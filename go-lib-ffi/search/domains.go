@@ -0,0 +1,64 @@
+package search
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+func init() {
+	buildinfo.Register("group_by_domain")
+}
+
+// DomainGroup holds the results sharing a registrable domain
+type DomainGroup struct {
+	Domain  string         `json:"domain"`
+	Results []SearchResult `json:"results"`
+}
+
+// GroupResultsByDomain groups results by the registrable domain of their
+// link, so "docs.go.dev" and "go.dev" share the "go.dev" group while
+// "example.co.uk" is not merged with other ".co.uk" sites. Groups appear in
+// the order their first result does and keep their results in order.
+// IP addresses and hosts without a known public suffix are grouped by the
+// host itself; results without a parseable host share the "" group.
+func GroupResultsByDomain(results []SearchResult) []DomainGroup {
+	groups := []DomainGroup{}
+	index := make(map[string]int)
+
+	for _, result := range results {
+		domain := registrableDomain(result.Link)
+		i, ok := index[domain]
+		if !ok {
+			i = len(groups)
+			index[domain] = i
+			groups = append(groups, DomainGroup{Domain: domain})
+		}
+		groups[i].Results = append(groups[i].Results, result)
+	}
+
+	return groups
+}
+
+// registrableDomain returns the lowercased registrable domain of link's
+// host (eTLD+1), the host itself when it has none, or ""
+func registrableDomain(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
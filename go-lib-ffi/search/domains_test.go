@@ -0,0 +1,35 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupResultsByDomain(t *testing.T) {
+	results := []SearchResult{
+		{Title: "Go", Link: "https://go.dev/", Position: 1},
+		{Title: "BBC News", Link: "https://www.bbc.co.uk/news", Position: 2},
+		{Title: "Go docs", Link: "https://pkg.go.dev/net/url", Position: 3},
+		{Title: "Other UK site", Link: "https://example.co.uk/", Position: 4},
+		{Title: "BBC Sport", Link: "http://SPORT.bbc.co.uk./football", Position: 5},
+		{Title: "Local", Link: "http://192.168.1.10:8080/admin", Position: 6},
+		{Title: "Broken", Link: "not a url", Position: 7},
+	}
+
+	expected := []DomainGroup{
+		{Domain: "go.dev", Results: []SearchResult{results[0], results[2]}},
+		{Domain: "bbc.co.uk", Results: []SearchResult{results[1], results[4]}},
+		{Domain: "example.co.uk", Results: []SearchResult{results[3]}},
+		{Domain: "192.168.1.10", Results: []SearchResult{results[5]}},
+		{Domain: "", Results: []SearchResult{results[6]}},
+	}
+
+	groups := GroupResultsByDomain(results)
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("GroupResultsByDomain() failed\nExpected: %+v\nGot:      %+v", expected, groups)
+	}
+
+	if groups := GroupResultsByDomain(nil); groups == nil || len(groups) != 0 {
+		t.Errorf("GroupResultsByDomain() failed\nInput:    nil\nExpected: empty slice\nGot:      %#v", groups)
+	}
+}
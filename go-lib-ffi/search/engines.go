@@ -0,0 +1,99 @@
+package search
+
+import (
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+
+	"golang.org/x/net/html"
+)
+
+// EngineParser parses a results page of one search engine.
+// Parsers other than DuckDuckGo's apply only opts.MaxFieldBytes.
+type EngineParser func(htmlStr string, maxResults int, opts SearchOptions) []SearchResult
+
+// engineParsers maps engine names to their parsers
+var engineParsers = map[string]EngineParser{}
+
+// registerEngine makes a parser available to ParseEngineResults
+// and advertises it as a capability
+func registerEngine(name string, parser EngineParser) {
+	engineParsers[name] = parser
+	buildinfo.Register(buildinfo.EnginePrefix + name)
+}
+
+// ParseEngineResults parses htmlStr with the parser registered for engine.
+// Engine names are case-insensitive. Unknown engines yield an empty slice
+// and false.
+func ParseEngineResults(engine, htmlStr string, maxResults int) ([]SearchResult, bool) {
+	return ParseEngineResultsWithOptions(engine, htmlStr, maxResults, SearchOptions{})
+}
+
+// ParseEngineResultsWithOptions parses htmlStr like ParseEngineResults,
+// passing opts to the engine's parser (see EngineParser)
+func ParseEngineResultsWithOptions(engine, htmlStr string, maxResults int, opts SearchOptions) ([]SearchResult, bool) {
+	parser, ok := engineParsers[strings.ToLower(strings.TrimSpace(engine))]
+	if !ok {
+		return []SearchResult{}, false
+	}
+
+	results := parser(htmlStr, maxResults, opts)
+	if results == nil {
+		results = []SearchResult{}
+	}
+	return results, true
+}
+
+// collectResults parses htmlStr and builds a result from every element
+// isResult accepts, in document order, with parse, which reads each field
+// with at most maxFieldBytes bytes. Elements inside an accepted one are not
+// visited. Results without a title or a valid link are skipped without
+// consuming a position. maxResults defaults to 20.
+func collectResults(htmlStr string, maxResults, maxFieldBytes int, isResult func(*html.Node) bool, parse func(*html.Node, int) SearchResult) []SearchResult {
+	results := []SearchResult{}
+	if strings.TrimSpace(htmlStr) == "" {
+		return results
+	}
+
+	if maxResults <= 0 {
+		maxResults = 20
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return results
+	}
+
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && isResult(node) {
+			result := parse(node, maxFieldBytes)
+			if result.Title != "" && isValidResultLink(result.Link, DefaultAllowedSchemes) {
+				result.Position = len(results) + 1
+				results = append(results, result)
+			}
+			return
+		}
+
+		for child := node.FirstChild; child != nil && len(results) < maxResults; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	return results
+}
+
+// findFirst returns the first element below node, node included, that
+// match accepts, or nil
+func findFirst(node *html.Node, match func(*html.Node) bool) *html.Node {
+	if node.Type == html.ElementNode && match(node) {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findFirst(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEngineResults(t *testing.T) {
+	ddgHTML := `<div class="result"><a class="result__a" href="https://example.com/ddg">DDG result</a></div>`
+	googleHTML := `<div class="g"><a href="https://example.com/g"><h3>Google result</h3></a></div>`
+	bingHTML := `<li class="b_algo"><h2><a href="https://example.com/b">Bing result</a></h2></li>`
+	liteHTML := `<table><tr><td><a class="result-link" href="https://example.com/l">Lite result</a></td></tr></table>`
+	redditHTML := `<div class="thing link" data-subreddit="golang" data-permalink="/r/golang/comments/x/post/"><a class="title" href="https://example.com">Reddit post</a></div>`
+
+	tests := []struct {
+		name          string
+		engine        string
+		input         string
+		expectedOK    bool
+		expectedTitle string
+	}{
+		{
+			name:          "duckduckgo",
+			engine:        "duckduckgo",
+			input:         ddgHTML,
+			expectedOK:    true,
+			expectedTitle: "DDG result",
+		},
+		{
+			name:          "engine names are case-insensitive",
+			engine:        " DuckDuckGo ",
+			input:         ddgHTML,
+			expectedOK:    true,
+			expectedTitle: "DDG result",
+		},
+		{
+			name:          "google",
+			engine:        "google",
+			input:         googleHTML,
+			expectedOK:    true,
+			expectedTitle: "Google result",
+		},
+		{
+			name:          "bing",
+			engine:        "bing",
+			input:         bingHTML,
+			expectedOK:    true,
+			expectedTitle: "Bing result",
+		},
+		{
+			name:          "lite",
+			engine:        "lite",
+			input:         liteHTML,
+			expectedOK:    true,
+			expectedTitle: "Lite result",
+		},
+		{
+			name:          "reddit",
+			engine:        "reddit",
+			input:         redditHTML,
+			expectedOK:    true,
+			expectedTitle: "Reddit post",
+		},
+		{
+			name:       "engine without results",
+			engine:     "reddit",
+			input:      ddgHTML,
+			expectedOK: true,
+		},
+		{
+			name:       "unknown engine",
+			engine:     "altavista",
+			input:      ddgHTML,
+			expectedOK: false,
+		},
+		{
+			name:       "empty engine",
+			engine:     "",
+			input:      ddgHTML,
+			expectedOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, ok := ParseEngineResults(tt.engine, tt.input, 10)
+			if ok != tt.expectedOK {
+				t.Errorf("ParseEngineResults() failed\nEngine:   %q\nExpected: ok=%v\nGot:      ok=%v", tt.engine, tt.expectedOK, ok)
+			}
+			if results == nil {
+				t.Fatalf("ParseEngineResults() failed\nEngine:   %q\nExpected: non-nil slice\nGot:      nil", tt.engine)
+			}
+
+			var title string
+			if len(results) > 0 {
+				title = results[0].Title
+			}
+			if title != tt.expectedTitle {
+				t.Errorf("ParseEngineResults() failed\nEngine:   %q\nExpected: %q\nGot:      %q", tt.engine, tt.expectedTitle, title)
+			}
+		})
+	}
+}
+
+func TestParseEngineResultsMaxFieldBytes(t *testing.T) {
+	// About 1 MB of text in the title and snippet of every engine's result
+	huge := strings.Repeat("word ", 200000)
+	pages := map[string]string{
+		"duckduckgo": `<div class="result"><a class="result__a" href="https://example.com/">` + huge + `</a><a class="result__snippet">` + huge + `</a></div>`,
+		"google":     `<div class="g"><a href="https://example.com/"><h3>` + huge + `</h3></a><div class="VwiC3b">` + huge + `</div></div>`,
+		"bing":       `<li class="b_algo"><h2><a href="https://example.com/">` + huge + `</a></h2><div class="b_caption"><p>` + huge + `</p></div></li>`,
+		"lite":       `<table><tr><td><a class="result-link" href="https://example.com/">` + huge + `</a></td></tr><tr><td class="result-snippet">` + huge + `</td></tr></table>`,
+		"reddit":     `<div class="thing link" data-permalink="/r/golang/comments/x/post/"><a class="title" href="https://example.com/">` + huge + `</a><a class="subreddit">` + huge + `</a></div>`,
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int
+		expected int
+	}{
+		{
+			name:     "default cap",
+			maxBytes: 0,
+			expected: DefaultMaxFieldBytes,
+		},
+		{
+			name:     "custom cap",
+			maxBytes: 100,
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		for engine, page := range pages {
+			t.Run(tt.name+"/"+engine, func(t *testing.T) {
+				results, _ := ParseEngineResultsWithOptions(engine, page, 10, SearchOptions{MaxFieldBytes: tt.maxBytes})
+				if len(results) != 1 {
+					t.Fatalf("ParseEngineResultsWithOptions() failed\nEngine:   %q\nExpected: 1 result\nGot:      %d", engine, len(results))
+				}
+				for field, value := range map[string]string{"Title": results[0].Title, "Snippet": results[0].Snippet} {
+					if len(value) > tt.expected || len(value) < tt.expected-len("word ") {
+						t.Errorf("ParseEngineResultsWithOptions() failed\nEngine:   %q\nField:    %s\nExpected: about %d bytes\nGot:      %d bytes", engine, field, tt.expected, len(value))
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	registerEngine("google", func(htmlStr string, maxResults int, opts SearchOptions) []SearchResult {
+		return parseGoogleResults(htmlStr, maxResults, opts.fieldLimit())
+	})
+}
+
+// ParseGoogleResults parses Google search results HTML (div.g results).
+// Extracts the h3 title, the target of its link (unwrapping "/url?q="
+// redirects), the snippet (div.VwiC3b, or the older span.st) and the <cite>
+// display URL. Links back into Google, such as related searches, are skipped.
+// Handles up to maxResults (default 20) results, with fields capped at
+// DefaultMaxFieldBytes
+func ParseGoogleResults(htmlStr string, maxResults int) []SearchResult {
+	return parseGoogleResults(htmlStr, maxResults, DefaultMaxFieldBytes)
+}
+
+// parseGoogleResults parses results like ParseGoogleResults with fields
+// capped at maxFieldBytes (see extractBoundedText)
+func parseGoogleResults(htmlStr string, maxResults, maxFieldBytes int) []SearchResult {
+	return collectResults(htmlStr, maxResults, maxFieldBytes, func(n *html.Node) bool {
+		return n.Data == "div" && hasClass(n, "g")
+	}, parseGoogleResult)
+}
+
+// parseGoogleResult extracts data from a single div.g result
+func parseGoogleResult(div *html.Node, maxFieldBytes int) SearchResult {
+	var result SearchResult
+
+	if h3 := findFirst(div, func(n *html.Node) bool { return n.Data == "h3" }); h3 != nil {
+		result.Title = extractBoundedText(h3, maxFieldBytes)
+		for a := h3.Parent; a != nil && a != div.Parent; a = a.Parent {
+			if a.Type == html.ElementNode && a.Data == "a" {
+				result.Link = googleResultLink(getAttr(a, "href"))
+				break
+			}
+		}
+	}
+
+	snippet := findFirst(div, func(n *html.Node) bool {
+		return hasClass(n, "VwiC3b") || (n.Data == "span" && hasClass(n, "st"))
+	})
+	if snippet != nil {
+		result.Snippet = extractBoundedText(snippet, maxFieldBytes)
+	}
+
+	if cite := findFirst(div, func(n *html.Node) bool { return n.Data == "cite" }); cite != nil {
+		result.DisplayURL = extractBoundedText(cite, maxFieldBytes)
+	}
+
+	return result
+}
+
+// googleResultLink returns the target of a Google result href. Relative
+// "/url?q=" redirects are unwrapped; other relative links point back into
+// Google and yield "".
+func googleResultLink(href string) string {
+	if strings.HasPrefix(href, "/url?") {
+		href = "https://www.google.com" + href
+	} else if strings.HasPrefix(href, "/") {
+		return ""
+	}
+	return UnwrapRedirect(href)
+}
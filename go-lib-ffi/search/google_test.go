@@ -0,0 +1,73 @@
+package search
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseGoogleResults(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/google_results.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	expected := []SearchResult{
+		{
+			Title:    "Tutorial: Get started with Go",
+			Link:     "https://go.dev/doc/tutorial/getting-started",
+			Snippet:  "In this tutorial, you'll get a brief introduction to Go programming.",
+			Position: 1,
+		},
+		{
+			Title:    "Go by Example",
+			Link:     "https://gobyexample.com/",
+			Snippet:  "Go by Example is a hands-on introduction to Go using annotated example programs.",
+			Position: 2,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		maxResults int
+		expected   []SearchResult
+	}{
+		{
+			name:       "results fixture",
+			input:      string(fixture),
+			maxResults: 10,
+			expected:   expected,
+		},
+		{
+			name:       "limit results",
+			input:      string(fixture),
+			maxResults: 1,
+			expected:   expected[:1],
+		},
+		{
+			name:       "empty input",
+			input:      "",
+			maxResults: 5,
+			expected:   []SearchResult{},
+		},
+		{
+			name: "redirect link, cite and internal link",
+			input: `<div class="g"><a href="/url?q=https://example.com/a&amp;sa=U"><h3>Redirected</h3><cite>example.com › a</cite></a><span class="st">Old snippet</span></div>` +
+				`<div class="g"><a href="/search?q=related"><h3>Related searches</h3></a></div>`,
+			maxResults: 5,
+			expected: []SearchResult{
+				{Title: "Redirected", Link: "https://example.com/a", Snippet: "Old snippet", DisplayURL: "example.com › a", Position: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseGoogleResults(tt.input, tt.maxResults)
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("ParseGoogleResults() failed\nExpected: %+v\nGot:      %+v", tt.expected, results)
+			}
+		})
+	}
+}
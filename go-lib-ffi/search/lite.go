@@ -0,0 +1,97 @@
+package search
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	registerEngine("lite", func(htmlStr string, maxResults int, opts SearchOptions) []SearchResult {
+		return parseLiteResults(htmlStr, maxResults, opts.fieldLimit())
+	})
+}
+
+// ParseLiteResults parses DuckDuckGo Lite results HTML (lite.duckduckgo.com),
+// where each result spans table rows: an a.result-link title, a
+// td.result-snippet and a span.link-text display URL. Sponsored rows
+// (tr.result-sponsored) are skipped.
+// Handles up to maxResults (default 20) results, with fields capped at
+// DefaultMaxFieldBytes
+func ParseLiteResults(htmlStr string, maxResults int) []SearchResult {
+	return parseLiteResults(htmlStr, maxResults, DefaultMaxFieldBytes)
+}
+
+// parseLiteResults parses results like ParseLiteResults with fields
+// capped at maxFieldBytes (see extractBoundedText)
+func parseLiteResults(htmlStr string, maxResults, maxFieldBytes int) []SearchResult {
+	results := []SearchResult{}
+	if strings.TrimSpace(htmlStr) == "" {
+		return results
+	}
+
+	if maxResults <= 0 {
+		maxResults = 20
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return results
+	}
+
+	// The rows of one result follow its title link, so every snippet and
+	// display URL belongs to the latest title seen
+	var current *SearchResult
+	var parsed []SearchResult
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch {
+			case node.Data == "a" && hasClass(node, "result-link"):
+				current = nil
+				if isSponsoredLiteRow(node) {
+					return
+				}
+				parsed = append(parsed, SearchResult{
+					Title: extractBoundedText(node, maxFieldBytes),
+					Link:  cleanDuckDuckGoURL(getAttr(node, "href")),
+				})
+				current = &parsed[len(parsed)-1]
+				return
+			case current != nil && node.Data == "td" && hasClass(node, "result-snippet"):
+				current.Snippet = extractBoundedText(node, maxFieldBytes)
+				return
+			case current != nil && node.Data == "span" && hasClass(node, "link-text"):
+				current.DisplayURL = extractBoundedText(node, maxFieldBytes)
+				return
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	for _, result := range parsed {
+		if len(results) >= maxResults {
+			break
+		}
+		if result.Title != "" && isValidResultLink(result.Link, DefaultAllowedSchemes) {
+			result.Position = len(results) + 1
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// isSponsoredLiteRow reports whether link sits in a tr.result-sponsored row
+func isSponsoredLiteRow(link *html.Node) bool {
+	for n := link.Parent; n != nil; n = n.Parent {
+		if n.Type == html.ElementNode && n.Data == "tr" {
+			return hasClass(n, "result-sponsored")
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseLiteResults(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/lite_results.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	expected := []SearchResult{
+		{
+			Title:      "Tutorial: Get started with Go",
+			Link:       "https://go.dev/doc/tutorial/getting-started",
+			Snippet:    "In this tutorial, you'll get a brief introduction to Go programming.",
+			DisplayURL: "go.dev/doc/tutorial/getting-started",
+			Position:   1,
+		},
+		{
+			Title:      "Go by Example",
+			Link:       "https://gobyexample.com/",
+			Snippet:    "Go by Example is a hands-on introduction to Go using annotated example programs.",
+			DisplayURL: "gobyexample.com",
+			Position:   2,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		maxResults int
+		expected   []SearchResult
+	}{
+		{
+			name:       "results fixture",
+			input:      string(fixture),
+			maxResults: 10,
+			expected:   expected,
+		},
+		{
+			name:       "limit results",
+			input:      string(fixture),
+			maxResults: 1,
+			expected:   expected[:1],
+		},
+		{
+			name:       "empty input",
+			input:      "",
+			maxResults: 5,
+			expected:   []SearchResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseLiteResults(tt.input, tt.maxResults)
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("ParseLiteResults() failed\nExpected: %+v\nGot:      %+v", tt.expected, results)
+			}
+		})
+	}
+}
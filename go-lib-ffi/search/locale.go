@@ -0,0 +1,138 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SnippetLocale names the number and date conventions a snippet is written in
+type SnippetLocale string
+
+const (
+	// SnippetLocaleUS reads "1,000.5" as a number and "12/31/2024" as month/day/year
+	SnippetLocaleUS SnippetLocale = "us"
+
+	// SnippetLocaleEU reads "1.000,5" as a number and "31.12.2024" or
+	// "31/12/2024" as day/month/year
+	SnippetLocaleEU SnippetLocale = "eu"
+)
+
+var (
+	// numberCandidate matches runs of digits joined by separators
+	numberCandidate = regexp.MustCompile(`\d[\d.,/]*\d`)
+
+	euGroupedNumber = regexp.MustCompile(`^\d{1,3}(\.\d{3})+(,\d+)?$`)
+	usDate          = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4})$`)
+	euDate          = regexp.MustCompile(`^(\d{1,2})[./](\d{1,2})[./](\d{4})$`)
+)
+
+// NormalizeSnippetNumbers rewrites the numbers and dates of text written in
+// locale to one form: grouped numbers as "1,000,000.5" and dates as
+// "2024-12-31". Only unambiguous patterns are rewritten, i.e. numbers with
+// thousands separators and complete dates with a valid day and month;
+// version strings such as "1.2.3" and numbers without separators are kept.
+// Unknown locales return text unchanged.
+func NormalizeSnippetNumbers(text string, locale SnippetLocale) string {
+	if locale != SnippetLocaleUS && locale != SnippetLocaleEU {
+		return text
+	}
+
+	matches := numberCandidate.FindAllStringIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if !isNumberBoundary(text, start, end) {
+			continue
+		}
+
+		token := text[start:end]
+		normalized := normalizeNumberToken(token, locale)
+		if normalized == token {
+			continue
+		}
+
+		sb.WriteString(text[last:start])
+		sb.WriteString(normalized)
+		last = end
+	}
+
+	if last == 0 {
+		return text
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// normalizeNumberToken rewrites a single number or date token
+func normalizeNumberToken(token string, locale SnippetLocale) string {
+	switch locale {
+	case SnippetLocaleUS:
+		if m := usDate.FindStringSubmatch(token); m != nil {
+			return isoDate(m[3], m[1], m[2], token)
+		}
+
+	case SnippetLocaleEU:
+		if euGroupedNumber.MatchString(token) {
+			return strings.NewReplacer(".", ",", ",", ".").Replace(token)
+		}
+		if m := euDate.FindStringSubmatch(token); m != nil {
+			return isoDate(m[3], m[2], m[1], token)
+		}
+	}
+
+	// US grouped numbers are already in the normalized form
+	return token
+}
+
+// isoDate formats year, month and day as YYYY-MM-DD, or returns fallback
+// when they do not form a valid date
+func isoDate(year, month, day, fallback string) string {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if m < 1 || m > 12 || d < 1 || d > daysIn(m, y) {
+		return fallback
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", y, m, d)
+}
+
+// daysIn returns the number of days of month in year
+func daysIn(month, year int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// isNumberBoundary reports whether text[start:end] stands alone rather than
+// being part of a word or following a separator, as in "v1.000" or ".5.000"
+func isNumberBoundary(text string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == ',' || r == '/' {
+			return false
+		}
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
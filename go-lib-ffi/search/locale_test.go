@@ -0,0 +1,112 @@
+package search
+
+import "testing"
+
+func TestNormalizeSnippetNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		locale   SnippetLocale
+		expected string
+	}{
+		{
+			name:     "US numbers kept under US locale",
+			input:    "Over 1,000,000 downloads at $1,299.99 each",
+			locale:   SnippetLocaleUS,
+			expected: "Over 1,000,000 downloads at $1,299.99 each",
+		},
+		{
+			name:     "EU numbers kept under US locale",
+			input:    "Über 1.000.000 Downloads für 1.299,99 €",
+			locale:   SnippetLocaleUS,
+			expected: "Über 1.000.000 Downloads für 1.299,99 €",
+		},
+		{
+			name:     "EU numbers normalized under EU locale",
+			input:    "Über 1.000.000 Downloads für 1.299,99 €",
+			locale:   SnippetLocaleEU,
+			expected: "Über 1,000,000 Downloads für 1,299.99 €",
+		},
+		{
+			name:     "US numbers kept under EU locale",
+			input:    "Over 1,000,000 downloads at $1,299.99 each",
+			locale:   SnippetLocaleEU,
+			expected: "Over 1,000,000 downloads at $1,299.99 each",
+		},
+		{
+			name:     "US date under US locale",
+			input:    "Released 12/31/2024.",
+			locale:   SnippetLocaleUS,
+			expected: "Released 2024-12-31.",
+		},
+		{
+			name:     "EU dates under EU locale",
+			input:    "Veröffentlicht am 31.12.2024 bzw. 1/2/2025",
+			locale:   SnippetLocaleEU,
+			expected: "Veröffentlicht am 2024-12-31 bzw. 2025-02-01",
+		},
+		{
+			name:     "invalid date kept",
+			input:    "Released 31/12/2024",
+			locale:   SnippetLocaleUS,
+			expected: "Released 31/12/2024",
+		},
+		{
+			name:     "leap day",
+			input:    "29.02.2024 and 29.02.2023",
+			locale:   SnippetLocaleEU,
+			expected: "2024-02-29 and 29.02.2023",
+		},
+		{
+			name:     "versions and identifiers kept",
+			input:    "Go 1.22.100 and build v1.000 and 2.000.5",
+			locale:   SnippetLocaleEU,
+			expected: "Go 1.22.100 and build v1.000 and 2.000.5",
+		},
+		{
+			name:     "decimal comma without grouping kept",
+			input:    "Pages 3,14 and 2,5",
+			locale:   SnippetLocaleEU,
+			expected: "Pages 3,14 and 2,5",
+		},
+		{
+			name:     "no locale",
+			input:    "1.000.000 on 31.12.2024",
+			locale:   "",
+			expected: "1.000.000 on 31.12.2024",
+		},
+		{
+			name:     "unknown locale",
+			input:    "1.000.000 on 31.12.2024",
+			locale:   "fr",
+			expected: "1.000.000 on 31.12.2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizeSnippetNumbers(tt.input, tt.locale)
+			if result != tt.expected {
+				t.Errorf("NormalizeSnippetNumbers() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsLocale(t *testing.T) {
+	input := `<div class="result"><a class="result__a" href="https://example.com/">Preise 2024</a>` +
+		`<a class="result__snippet">Stand 01.03.2024: 12.500,50 Nutzer</a></div>`
+
+	results := ParseSearchResultsWithOptions(input, 10, SearchOptions{Locale: SnippetLocaleEU})
+	if len(results) != 1 {
+		t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: 1 result\nGot:      %d", len(results))
+	}
+
+	expected := "Stand 2024-03-01: 12,500.50 Nutzer"
+	if results[0].Snippet != expected {
+		t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %q\nGot:      %q", expected, results[0].Snippet)
+	}
+	if results[0].Title != "Preise 2024" {
+		t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %q\nGot:      %q", "Preise 2024", results[0].Title)
+	}
+}
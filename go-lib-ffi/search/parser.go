@@ -1,19 +1,114 @@
 package search
 
 import (
+	"cmp"
 	"net/url"
+	"regexp"
 	"slices"
 	"strings"
+	"unicode/utf8"
+
+	"go-lib-ffi/textutil"
 
 	"golang.org/x/net/html"
 )
 
+func init() {
+	registerEngine("duckduckgo", ParseSearchResultsWithOptions)
+}
+
 // SearchResult represents a parsed search result
 type SearchResult struct {
 	Title    string
 	Link     string
 	Snippet  string
 	Position int
+
+	// DisplayURL is the human-readable URL DuckDuckGo shows under the
+	// title (a.result__url), often in breadcrumb form such as
+	// "example.com › docs › page". Empty when the page does not show one.
+	DisplayURL string `json:",omitempty"`
+
+	// Score rates how well the result matches SearchOptions.Query,
+	// higher is better. It is 0 when no query is given.
+	Score float64 `json:",omitempty"`
+
+	// RawHTML is the outer HTML of the result element, set only when
+	// SearchOptions.IncludeRawHTML is enabled
+	RawHTML string `json:",omitempty"`
+}
+
+// DefaultMaxFieldBytes caps the text gathered for each result field when
+// SearchOptions.MaxFieldBytes is 0
+const DefaultMaxFieldBytes = 16 << 10
+
+// DefaultAllowedSchemes lists the link schemes accepted when
+// SearchOptions.AllowedSchemes is empty
+var DefaultAllowedSchemes = []string{"http", "https"}
+
+// SearchOptions controls optional behavior of ParseSearchResultsWithOptions.
+// The zero value produces the same output as ParseSearchResults.
+type SearchOptions struct {
+	// AllowedSchemes lists the URL schemes a result link may use.
+	// Results with other schemes (javascript:, mailto:, schemeless paths)
+	// are dropped without consuming a position.
+	// An empty list means DefaultAllowedSchemes.
+	AllowedSchemes []string `json:"allowed_schemes"`
+
+	// Limits rejects oversized inputs before they are parsed.
+	// The zero value sets no limits.
+	Limits textutil.Limits `json:"limits"`
+
+	// IncludeRawHTML fills SearchResult.RawHTML with the markup each result
+	// was parsed from, for debugging odd results. It is opt-in because it
+	// multiplies the output size.
+	IncludeRawHTML bool `json:"include_raw_html"`
+
+	// MinTitleChars and MinSnippetChars drop results whose title or snippet
+	// has fewer characters, such as empty or one-word snippets, without
+	// consuming a position. Lengths are measured before MaxSnippetChars
+	// shortens the snippet. 0 accepts any length.
+	MinTitleChars   int `json:"min_title_chars"`
+	MinSnippetChars int `json:"min_snippet_chars"`
+
+	// MaxSnippetChars shortens snippets longer than this many characters
+	// on a word boundary, ending them with textutil.Ellipsis.
+	// 0 keeps snippets whole.
+	MaxSnippetChars int `json:"max_snippet_chars"`
+
+	// TruncateAtSentence makes MaxSnippetChars cut after the last whole
+	// sentence that fits (see textutil.TruncateSentences), falling back to
+	// a word boundary when none does.
+	TruncateAtSentence bool `json:"truncate_at_sentence"`
+
+	// MaxFieldBytes bounds the text gathered for the title, snippet and
+	// display URL of each result, so a malformed page with megabytes of text
+	// in one element cannot balloon a result. Text past the cap is dropped
+	// on a rune boundary while it is collected. Every engine parser of
+	// ParseEngineResultsWithOptions applies it.
+	// 0 means DefaultMaxFieldBytes; a negative value disables the cap.
+	MaxFieldBytes int `json:"max_field_bytes"`
+
+	// Locale normalizes grouped numbers and dates in snippets written with
+	// its conventions (see NormalizeSnippetNumbers). Empty leaves them as is.
+	Locale SnippetLocale `json:"locale"`
+
+	// Query scores every result against the search terms (see SearchResult.Score)
+	Query string `json:"query"`
+
+	// SortByScore orders results by descending Score instead of by position.
+	// Results with equal scores keep their page order and every result keeps
+	// its original Position.
+	SortByScore bool `json:"sort_by_score"`
+}
+
+// fieldLimit returns opts.MaxFieldBytes with DefaultMaxFieldBytes applied
+// for 0, as passed to extractBoundedText
+func (opts SearchOptions) fieldLimit() int {
+	if opts.MaxFieldBytes == 0 {
+		return DefaultMaxFieldBytes
+	}
+	return opts.MaxFieldBytes
 }
 
 // ParseSearchResults parses DuckDuckGo search results HTML
@@ -21,8 +116,25 @@ type SearchResult struct {
 // Handles up to maxResults (default 20) results
 // Returns array of SearchResult
 func ParseSearchResults(htmlStr string, maxResults int) []SearchResult {
+	return ParseSearchResultsWithOptions(htmlStr, maxResults, SearchOptions{})
+}
+
+// ParseSearchResultsWithOptions parses DuckDuckGo search results HTML like
+// ParseSearchResults with the behaviors selected in opts
+func ParseSearchResultsWithOptions(htmlStr string, maxResults int, opts SearchOptions) []SearchResult {
+	results, _ := ParseSearchResultsChecked(htmlStr, maxResults, opts)
+	return results
+}
+
+// ParseSearchResultsChecked parses results like ParseSearchResultsWithOptions and
+// reports inputs rejected by opts.Limits with a *textutil.LimitError and no results
+func ParseSearchResultsChecked(htmlStr string, maxResults int, opts SearchOptions) ([]SearchResult, error) {
 	if strings.TrimSpace(htmlStr) == "" {
-		return []SearchResult{}
+		return []SearchResult{}, nil
+	}
+
+	if err := opts.Limits.Check(htmlStr); err != nil {
+		return []SearchResult{}, err
 	}
 
 	if maxResults <= 0 {
@@ -32,11 +144,19 @@ func ParseSearchResults(htmlStr string, maxResults int) []SearchResult {
 	// Parse the HTML
 	doc, err := html.Parse(strings.NewReader(htmlStr))
 	if err != nil {
-		return []SearchResult{}
+		return []SearchResult{}, nil
+	}
+
+	allowedSchemes := opts.AllowedSchemes
+	if len(allowedSchemes) == 0 {
+		allowedSchemes = DefaultAllowedSchemes
 	}
 
 	var results []SearchResult
 	position := 1
+	terms := queryTerms(opts.Query)
+
+	maxFieldBytes := opts.fieldLimit()
 
 	// Find all div.result elements
 	var findResultDivs func(*html.Node)
@@ -47,9 +167,21 @@ func ParseSearchResults(htmlStr string, maxResults int) []SearchResult {
 
 		if node.Type == html.ElementNode && node.Data == "div" && hasClass(node, "result") {
 			// Parse this result
-			result := parseResultDiv(node)
-			if result.Title != "" && result.Link != "" && result.Link != "#" && !strings.Contains(result.Link, "y.js") {
+			result := parseResultDiv(node, maxFieldBytes)
+			if result.Title != "" && isValidResultLink(result.Link, allowedSchemes) &&
+				utf8.RuneCountInString(result.Title) >= opts.MinTitleChars &&
+				utf8.RuneCountInString(result.Snippet) >= opts.MinSnippetChars {
 				result.Position = position
+				result.Snippet = NormalizeSnippetNumbers(result.Snippet, opts.Locale)
+				result.Score = scoreResult(result, terms)
+				if opts.TruncateAtSentence {
+					result.Snippet = textutil.TruncateSentences(result.Snippet, opts.MaxSnippetChars)
+				} else {
+					result.Snippet = textutil.TruncateWords(result.Snippet, opts.MaxSnippetChars)
+				}
+				if opts.IncludeRawHTML {
+					result.RawHTML = renderNode(node)
+				}
 				results = append(results, result)
 				position++
 			}
@@ -68,11 +200,17 @@ func ParseSearchResults(htmlStr string, maxResults int) []SearchResult {
 		results = results[:maxResults]
 	}
 
-	return results
+	if opts.SortByScore {
+		slices.SortStableFunc(results, func(a, b SearchResult) int {
+			return cmp.Compare(b.Score, a.Score)
+		})
+	}
+
+	return results, nil
 }
 
 // parseResultDiv extracts data from a single result div
-func parseResultDiv(div *html.Node) SearchResult {
+func parseResultDiv(div *html.Node, maxFieldBytes int) SearchResult {
 	var result SearchResult
 
 	// Find title link (a.result__a)
@@ -80,7 +218,7 @@ func parseResultDiv(div *html.Node) SearchResult {
 	findTitleLink = func(node *html.Node) {
 		if node.Type == html.ElementNode && node.Data == "a" && hasClass(node, "result__a") {
 			// Extract title
-			result.Title = extractTextContent(node)
+			result.Title = extractBoundedText(node, maxFieldBytes)
 			// Extract and clean URL
 			for _, attr := range node.Attr {
 				if attr.Key == "href" {
@@ -100,7 +238,7 @@ func parseResultDiv(div *html.Node) SearchResult {
 	var findSnippetLink func(*html.Node)
 	findSnippetLink = func(node *html.Node) {
 		if node.Type == html.ElementNode && node.Data == "a" && hasClass(node, "result__snippet") {
-			result.Snippet = extractTextContent(node)
+			result.Snippet = extractBoundedText(node, maxFieldBytes)
 			return
 		}
 
@@ -109,28 +247,79 @@ func parseResultDiv(div *html.Node) SearchResult {
 		}
 	}
 
+	// Find display URL link (a.result__url)
+	var findDisplayURL func(*html.Node)
+	findDisplayURL = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.Data == "a" && hasClass(node, "result__url") {
+			result.DisplayURL = extractBoundedText(node, maxFieldBytes)
+			return
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			findDisplayURL(child)
+		}
+	}
+
 	findTitleLink(div)
 	findSnippetLink(div)
+	findDisplayURL(div)
 
 	return result
 }
 
+// renderNode returns the outer HTML of node, or an empty string if it cannot be rendered
+func renderNode(node *html.Node) string {
+	var sb strings.Builder
+	if err := html.Render(&sb, node); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// leftoverReference matches a character reference still present in parsed
+// text, which the page must have double-encoded. The terminating semicolon
+// is required: legacy entities such as "&not" or "&para" without one are
+// ordinary text by then.
+var leftoverReference = regexp.MustCompile(`&(#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+
 // extractTextContent extracts text content from HTML nodes
 func extractTextContent(node *html.Node) string {
-	var text strings.Builder
-	var walk func(*html.Node)
-	walk = func(n *html.Node) {
+	return extractBoundedText(node, -1)
+}
+
+// extractBoundedText extracts text content like extractTextContent, reading
+// at most maxBytes bytes of raw text. A maxBytes of 0 or less reads it all.
+func extractBoundedText(node *html.Node, maxBytes int) string {
+	text := textutil.GetBuffer()
+	defer textutil.PutBuffer(text)
+
+	var walk func(*html.Node) bool
+	walk = func(n *html.Node) bool {
 		if n.Type == html.TextNode {
-			text.WriteString(n.Data)
+			data := n.Data
+			if maxBytes > 0 && text.Len()+len(data) > maxBytes {
+				text.WriteString(data[:textutil.RuneBoundary(data, maxBytes-text.Len())])
+				return false
+			}
+			text.WriteString(data)
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			walk(c)
+			if !walk(c) {
+				return false
+			}
 		}
+		return true
 	}
 	walk(node)
 
+	// Text nodes are already decoded once by the parser; pages that
+	// double-encode entities ("&amp;#39;") leave complete references behind.
+	// Only those are decoded again, so text such as "&region=us" survives.
+	// Decoding before collapsing turns &nbsp; into collapsible space.
+	decoded := leftoverReference.ReplaceAllStringFunc(text.String(), html.UnescapeString)
+
 	// Collapse whitespace and trim
-	fields := strings.Fields(text.String())
+	fields := strings.Fields(decoded)
 	return strings.Join(fields, " ")
 }
 
@@ -140,25 +329,30 @@ func cleanDuckDuckGoURL(rawURL string) string {
 		return ""
 	}
 
-	// Check if it's a DuckDuckGo redirect URL
-	if strings.Contains(rawURL, "duckduckgo.com/l/?uddg=") {
-		parsed, err := url.Parse(rawURL)
-		if err != nil {
-			return rawURL
-		}
+	// Encoded redirect targets sometimes carry an HTML-escaped query separator.
+	// Only &amp; is decoded: unescaping the whole URL would turn parameters
+	// like "&copy=" into entities.
+	return strings.ReplaceAll(UnwrapRedirect(rawURL), "&amp;", "&")
+}
 
-		// Extract uddg parameter
-		uddg := parsed.Query().Get("uddg")
-		if uddg != "" {
-			// URL decode the actual URL
-			decoded, err := url.QueryUnescape(uddg)
-			if err == nil {
-				return decoded
-			}
-		}
+// isValidResultLink reports whether link is an absolute URL using one of
+// the allowed schemes. DuckDuckGo ad links (y.js) are always rejected.
+func isValidResultLink(link string, allowedSchemes []string) bool {
+	if link == "" || link == "#" || strings.Contains(link, "y.js") {
+		return false
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Scheme == "" {
+		return false
+	}
+	if parsed.Host == "" && parsed.Opaque == "" {
+		return false
 	}
 
-	return rawURL
+	return slices.ContainsFunc(allowedSchemes, func(scheme string) bool {
+		return strings.EqualFold(scheme, parsed.Scheme)
+	})
 }
 
 // hasClass checks if an HTML node has a specific CSS class.
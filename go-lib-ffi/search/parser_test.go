@@ -1,8 +1,15 @@
 package search
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"go-lib-ffi/textutil"
 
 	"golang.org/x/net/html"
 )
@@ -87,6 +94,11 @@ func TestCleanDuckDuckGoURL(t *testing.T) {
 			input:    "https://duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fpath%3Fq%3D123",
 			expected: "https://example.com/path?q=123",
 		},
+		{
+			name:     "result taking a url parameter kept",
+			input:    "https://validator.w3.org/check?url=https://example.com",
+			expected: "https://validator.w3.org/check?url=https://example.com",
+		},
 		{
 			name:     "empty URL",
 			input:    "",
@@ -145,3 +157,449 @@ func TestExtractTextContent(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkParseSearchResults(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 30; i++ {
+		sb.WriteString(`<div class="result results_links web-result">
+			<h2 class="result__title"><a class="result__a" href="https://duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fpage">Example <b>result</b> title</a></h2>
+			<a class="result__snippet" href="https://example.com/page">A snippet describing the <b>result</b> in a few words.</a>
+		</div>`)
+	}
+	input := sb.String()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ParseSearchResults(input, 30)
+	}
+}
+
+func TestParseSearchResultsLinkValidation(t *testing.T) {
+	htmlInput := `
+	<div class="result">
+		<a class="result__a" href="mailto:someone@example.com">Mail us</a>
+	</div>
+	<div class="result">
+		<a class="result__a" href="https://example.com/first">First</a>
+	</div>
+	<div class="result">
+		<a class="result__a" href="example.com/no-scheme">Schemeless</a>
+	</div>
+	<div class="result">
+		<a class="result__a" href="javascript:void(0)">Script</a>
+	</div>
+	<div class="result">
+		<a class="result__a" href="https:///missing-host">No host</a>
+	</div>
+	<div class="result">
+		<a class="result__a" href="http://example.org/second">Second</a>
+	</div>
+	`
+
+	results := ParseSearchResults(htmlInput, 10)
+	if len(results) != 2 {
+		t.Fatalf("ParseSearchResults() expected 2 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Link != "https://example.com/first" || results[0].Position != 1 {
+		t.Errorf("ParseSearchResults() first result mismatch: %+v", results[0])
+	}
+	if results[1].Link != "http://example.org/second" || results[1].Position != 2 {
+		t.Errorf("ParseSearchResults() second result mismatch: %+v", results[1])
+	}
+
+	// Allowing mailto keeps the mail link and still drops the others
+	results = ParseSearchResultsWithOptions(htmlInput, 10, SearchOptions{AllowedSchemes: []string{"https", "mailto"}})
+	if len(results) != 2 {
+		t.Fatalf("ParseSearchResultsWithOptions() expected 2 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Link != "mailto:someone@example.com" || results[1].Link != "https://example.com/first" {
+		t.Errorf("ParseSearchResultsWithOptions() unexpected results: %+v", results)
+	}
+}
+
+func TestParseSearchResultsChecked(t *testing.T) {
+	result := `<div class="result"><a class="result__a" href="https://example.com/a">A</a></div>`
+	bomb := result + strings.Repeat("<div><b>x</b></div>", 50000)
+
+	tests := []struct {
+		name          string
+		input         string
+		limits        textutil.Limits
+		expectedCount int
+		err           error
+	}{
+		{
+			name:          "no limits",
+			input:         bomb,
+			expectedCount: 1,
+		},
+		{
+			name:          "oversized input rejected",
+			input:         bomb,
+			limits:        textutil.Limits{MaxInputBytes: 4096},
+			expectedCount: 0,
+			err:           textutil.ErrInputTooLarge,
+		},
+		{
+			name:          "too many nodes rejected",
+			input:         bomb,
+			limits:        textutil.Limits{MaxNodes: 1000},
+			expectedCount: 0,
+			err:           textutil.ErrTooManyNodes,
+		},
+		{
+			name:          "small page within limits",
+			input:         result,
+			limits:        textutil.Limits{MaxInputBytes: 4096, MaxNodes: 1000},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ParseSearchResultsChecked(tt.input, 10, SearchOptions{Limits: tt.limits})
+			if len(results) != tt.expectedCount || !errors.Is(err, tt.err) {
+				t.Errorf("ParseSearchResultsChecked() failed\nExpected: %d results, %v\nGot:      %d results, %v", tt.expectedCount, tt.err, len(results), err)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsRawHTML(t *testing.T) {
+	input := `<div id="links">
+		<div class="result results_links"><a class="result__a" href="https://example.com/a">First &amp; best</a><a class="result__snippet">Snippet <b>A</b></a></div>
+		<div class="result"><a class="result__a" href="https://example.com/b">Second</a></div>
+	</div>`
+
+	results := ParseSearchResultsWithOptions(input, 10, SearchOptions{IncludeRawHTML: true})
+	if len(results) != 2 {
+		t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: 2 results\nGot:      %d", len(results))
+	}
+
+	expected := `<div class="result results_links"><a class="result__a" href="https://example.com/a">First &amp; best</a><a class="result__snippet">Snippet <b>A</b></a></div>`
+	if results[0].RawHTML != expected {
+		t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %s\nGot:      %s", expected, results[0].RawHTML)
+	}
+
+	// Parsing the raw HTML again must yield the same result
+	for _, result := range results {
+		reparsed := ParseSearchResults(result.RawHTML, 1)
+		if len(reparsed) != 1 {
+			t.Fatalf("ParseSearchResults() failed\nInput:    %s\nExpected: 1 result\nGot:      %d", result.RawHTML, len(reparsed))
+		}
+		got := reparsed[0]
+		if got.Title != result.Title || got.Link != result.Link || got.Snippet != result.Snippet {
+			t.Errorf("ParseSearchResults() failed\nInput:    %s\nExpected: %+v\nGot:      %+v", result.RawHTML, result, got)
+		}
+	}
+
+	// Raw HTML is opt-in
+	for _, result := range ParseSearchResults(input, 10) {
+		if result.RawHTML != "" {
+			t.Errorf("ParseSearchResults() failed\nExpected: empty RawHTML\nGot:      %s", result.RawHTML)
+		}
+	}
+}
+
+func TestSearchResultJSONOmitsEmptyRawHTML(t *testing.T) {
+	data, err := json.Marshal(SearchResult{Title: "T", Link: "https://example.com", Position: 1})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	expected := `{"Title":"T","Link":"https://example.com","Snippet":"","Position":1}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() failed\nExpected: %s\nGot:      %s", expected, data)
+	}
+}
+
+func TestParseSearchResultsMaxSnippetChars(t *testing.T) {
+	snippet := "Go is an open source programming language that makes it simple to build secure, scalable systems."
+	input := `<div class="result"><a class="result__a" href="https://go.dev">The Go Programming Language</a>` +
+		`<a class="result__snippet">` + snippet + `</a></div>`
+
+	tests := []struct {
+		name     string
+		maxChars int
+		expected string
+	}{
+		{
+			name:     "no limit",
+			maxChars: 0,
+			expected: snippet,
+		},
+		{
+			name:     "limit above the snippet length",
+			maxChars: 200,
+			expected: snippet,
+		},
+		{
+			name:     "limit at the snippet length",
+			maxChars: len(snippet),
+			expected: snippet,
+		},
+		{
+			name:     "limit below the snippet length",
+			maxChars: 40,
+			expected: "Go is an open source programming…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseSearchResultsWithOptions(input, 10, SearchOptions{MaxSnippetChars: tt.maxChars})
+			if len(results) != 1 {
+				t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: 1 result\nGot:      %d", len(results))
+			}
+			if results[0].Snippet != tt.expected {
+				t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %q\nGot:      %q", tt.expected, results[0].Snippet)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsTruncateAtSentence(t *testing.T) {
+	input := `<div class="result"><a class="result__a" href="https://go.dev">The Go Programming Language</a>` +
+		`<a class="result__snippet">Go is fast. It is also simple to learn and easy to deploy.</a></div>`
+
+	opts := SearchOptions{MaxSnippetChars: 40, TruncateAtSentence: true}
+	expected := "Go is fast."
+	results := ParseSearchResultsWithOptions(input, 10, opts)
+	if len(results) != 1 {
+		t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: 1 result\nGot:      %d", len(results))
+	}
+	if results[0].Snippet != expected {
+		t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %q\nGot:      %q", expected, results[0].Snippet)
+	}
+}
+
+func TestParseSearchResultsMinLengths(t *testing.T) {
+	result := func(title, link, snippet string) string {
+		return `<div class="result"><a class="result__a" href="` + link + `">` + title + `</a>` +
+			`<a class="result__snippet">` + snippet + `</a></div>`
+	}
+	input := result("Go", "https://a.example", "Go is an open source programming language.") +
+		result("Learn Go", "https://b.example", "Tutorial") +
+		result("Go by Example", "https://c.example", "") +
+		result("Effective Go", "https://d.example", "Tips for writing clear, idiomatic Go code.")
+
+	tests := []struct {
+		name     string
+		opts     SearchOptions
+		expected []string
+	}{
+		{
+			name:     "no minimum",
+			expected: []string{"https://a.example", "https://b.example", "https://c.example", "https://d.example"},
+		},
+		{
+			name:     "short snippets skipped",
+			opts:     SearchOptions{MinSnippetChars: 20},
+			expected: []string{"https://a.example", "https://d.example"},
+		},
+		{
+			name:     "short titles skipped",
+			opts:     SearchOptions{MinTitleChars: 5},
+			expected: []string{"https://b.example", "https://c.example", "https://d.example"},
+		},
+		{
+			name:     "both minimums",
+			opts:     SearchOptions{MinTitleChars: 5, MinSnippetChars: 1},
+			expected: []string{"https://b.example", "https://d.example"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseSearchResultsWithOptions(input, 10, tt.opts)
+			var links []string
+			for i, r := range results {
+				if r.Position != i+1 {
+					t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: position %d for %s\nGot:      %d", i+1, r.Link, r.Position)
+				}
+				links = append(links, r.Link)
+			}
+			if !slices.Equal(links, tt.expected) {
+				t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %v\nGot:      %v", tt.expected, links)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsMaxFieldBytes(t *testing.T) {
+	// A single text node of about 1 MB in both title and snippet
+	huge := strings.Repeat("é word ", 150000)
+	input := `<div class="result"><a class="result__a" href="https://example.com/">` + huge + `</a>` +
+		`<a class="result__snippet">` + huge + `</a></div>`
+
+	tests := []struct {
+		name     string
+		maxBytes int
+		expected int
+	}{
+		{
+			name:     "default cap",
+			maxBytes: 0,
+			expected: DefaultMaxFieldBytes,
+		},
+		{
+			name:     "custom cap",
+			maxBytes: 97,
+			expected: 97,
+		},
+		{
+			name:     "cap disabled",
+			maxBytes: -1,
+			expected: len(strings.TrimSpace(huge)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseSearchResultsWithOptions(input, 10, SearchOptions{MaxFieldBytes: tt.maxBytes})
+			if len(results) != 1 {
+				t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: 1 result\nGot:      %d", len(results))
+			}
+			for field, value := range map[string]string{"Title": results[0].Title, "Snippet": results[0].Snippet} {
+				if len(value) > tt.expected || len(value) < tt.expected-len("é word ") {
+					t.Errorf("ParseSearchResultsWithOptions() failed\nField:    %s\nExpected: about %d bytes\nGot:      %d bytes", field, tt.expected, len(value))
+				}
+				if !utf8.ValidString(value) {
+					t.Errorf("ParseSearchResultsWithOptions() failed\nField:    %s\nExpected: valid UTF-8\nGot:      %q", field, value[len(value)-4:])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsEntities(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedTitle   string
+		expectedSnippet string
+		expectedLink    string
+	}{
+		{
+			name: "single-encoded entities",
+			input: `<div class="result"><a class="result__a" href="https://example.com/">Tom &amp; Jerry&#39;s</a>` +
+				`<a class="result__snippet">It&#39;s &quot;fine&quot;</a></div>`,
+			expectedTitle:   "Tom & Jerry's",
+			expectedSnippet: `It's "fine"`,
+			expectedLink:    "https://example.com/",
+		},
+		{
+			name: "double-encoded entities",
+			input: `<div class="result"><a class="result__a" href="https://example.com/">Q&amp;amp;A &amp;#8211; Go</a>` +
+				`<a class="result__snippet">Don&amp;#39;t &amp;lt;panic&amp;gt;</a></div>`,
+			expectedTitle:   "Q&A – Go",
+			expectedSnippet: "Don't <panic>",
+			expectedLink:    "https://example.com/",
+		},
+		{
+			name: "non-breaking spaces collapse",
+			input: `<div class="result"><a class="result__a" href="https://example.com/">A&nbsp;&nbsp;B</a>` +
+				`<a class="result__snippet">one &amp;nbsp; two</a></div>`,
+			expectedTitle:   "A B",
+			expectedSnippet: "one two",
+			expectedLink:    "https://example.com/",
+		},
+		{
+			name: "entity-like text kept",
+			input: `<div class="result"><a class="result__a" href="https://example.com/">Use &amp;region=us &amp;not &amp;para</a>` +
+				`<a class="result__snippet">?lang=en&amp;region=us&amp;para=2 &amp;notes &amp;amp</a></div>`,
+			expectedTitle:   "Use &region=us &not &para",
+			expectedSnippet: "?lang=en&region=us&para=2 &notes &amp",
+			expectedLink:    "https://example.com/",
+		},
+		{
+			name:          "escaped separator in redirect target",
+			input:         `<div class="result"><a class="result__a" href="https://duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2F%3Fa%3D1%26amp%3Bb%3D2">Query</a></div>`,
+			expectedTitle: "Query",
+			expectedLink:  "https://example.com/?a=1&b=2",
+		},
+		{
+			name:          "entity-like parameters kept",
+			input:         `<div class="result"><a class="result__a" href="https://example.com/?x=1&amp;copy=2">Copy</a></div>`,
+			expectedTitle: "Copy",
+			expectedLink:  "https://example.com/?x=1&copy=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseSearchResults(tt.input, 5)
+			if len(results) != 1 {
+				t.Fatalf("ParseSearchResults() failed\nInput:    %s\nExpected: 1 result\nGot:      %d", tt.input, len(results))
+			}
+			got := results[0]
+			if got.Title != tt.expectedTitle || got.Snippet != tt.expectedSnippet || got.Link != tt.expectedLink {
+				t.Errorf("ParseSearchResults() failed\nInput:    %s\nExpected: %q %q %q\nGot:      %q %q %q",
+					tt.input, tt.expectedTitle, tt.expectedSnippet, tt.expectedLink, got.Title, got.Snippet, got.Link)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsDisplayURL(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/duckduckgo_results.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	expected := []struct {
+		link       string
+		displayURL string
+	}{
+		{link: "https://go.dev/doc/tutorial/getting-started", displayURL: "go.dev/doc/tutorial/getting-started"},
+		{link: "https://gobyexample.com/", displayURL: "gobyexample.com"},
+		{link: "https://www.example.com/learn/go?ref=ddg", displayURL: ""},
+	}
+
+	results := ParseSearchResults(string(fixture), 10)
+	if len(results) != len(expected) {
+		t.Fatalf("ParseSearchResults() failed\nExpected: %d results\nGot:      %d", len(expected), len(results))
+	}
+
+	for i, want := range expected {
+		if results[i].Link != want.link {
+			t.Errorf("ParseSearchResults() failed\nExpected Link: %q\nGot:           %q", want.link, results[i].Link)
+		}
+		if results[i].DisplayURL != want.displayURL {
+			t.Errorf("ParseSearchResults() failed\nExpected DisplayURL: %q\nGot:                 %q", want.displayURL, results[i].DisplayURL)
+		}
+	}
+}
+
+func FuzzParseSearchResults(f *testing.F) {
+	seeds := []string{
+		"",
+		`<div class="result"><a class="result__a" href="https://example.com">T</a><a class="result__snippet">S</a></div>`,
+		`<div class="result"><div class="result"><a class="result__a" href="javascript:x">T</a></div></div>`,
+		`<div class="result"><a class="result__a" href="//duckduckgo.com/l/?uddg=%zz">T</a></div>`,
+	}
+	for _, fixture := range []string{"testdata/duckduckgo_results.html", "testdata/reddit_listing.html"} {
+		if data, err := os.ReadFile(fixture); err == nil {
+			seeds = append(seeds, string(data))
+		}
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	opts := SearchOptions{
+		IncludeRawHTML:  true,
+		MaxSnippetChars: 20,
+		Locale:          SnippetLocaleEU,
+		Query:           "go tutorial",
+		SortByScore:     true,
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		ParseSearchResults(input, 5)
+		ParseSearchResultsWithOptions(input, 5, opts)
+		ParseRedditListing(input, 5)
+		NormalizeSnippetNumbers(input, SnippetLocaleUS)
+		NormalizeSnippetNumbers(input, SnippetLocaleEU)
+	})
+}
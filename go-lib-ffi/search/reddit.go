@@ -0,0 +1,142 @@
+package search
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+func init() {
+	registerEngine("reddit", func(htmlStr string, maxResults int, opts SearchOptions) []SearchResult {
+		return parseRedditListing(htmlStr, maxResults, opts.fieldLimit())
+	})
+}
+
+// redditBaseURL resolves the relative permalinks of old.reddit.com listings
+var redditBaseURL = &url.URL{Scheme: "https", Host: "old.reddit.com"}
+
+// ParseRedditListing parses old.reddit.com listing HTML (subreddit pages and
+// search results rendered as div.thing.link posts).
+// Extracts the post title, its absolute permalink, and the subreddit
+// ("r/golang") as the snippet. Promoted posts are skipped.
+// Handles up to maxResults (default 20) results, with fields capped at
+// DefaultMaxFieldBytes
+func ParseRedditListing(htmlStr string, maxResults int) []SearchResult {
+	return parseRedditListing(htmlStr, maxResults, DefaultMaxFieldBytes)
+}
+
+// parseRedditListing parses a listing like ParseRedditListing with fields
+// capped at maxFieldBytes (see extractBoundedText)
+func parseRedditListing(htmlStr string, maxResults, maxFieldBytes int) []SearchResult {
+	if strings.TrimSpace(htmlStr) == "" {
+		return []SearchResult{}
+	}
+
+	if maxResults <= 0 {
+		maxResults = 20
+	}
+
+	// Parse the HTML
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return []SearchResult{}
+	}
+
+	var results []SearchResult
+	position := 1
+
+	// Find all div.thing.link elements
+	var findPosts func(*html.Node)
+	findPosts = func(node *html.Node) {
+		if len(results) >= maxResults {
+			return
+		}
+
+		if node.Type == html.ElementNode && node.Data == "div" && hasClass(node, "thing") && hasClass(node, "link") {
+			if hasClass(node, "promoted") {
+				return
+			}
+
+			result := parseRedditPost(node, maxFieldBytes)
+			if result.Title != "" && isValidResultLink(result.Link, DefaultAllowedSchemes) {
+				result.Position = position
+				results = append(results, result)
+				position++
+			}
+			return
+		}
+
+		for child := node.FirstChild; child != nil && len(results) < maxResults; child = child.NextSibling {
+			findPosts(child)
+		}
+	}
+
+	findPosts(doc)
+
+	return results
+}
+
+// parseRedditPost extracts data from a single div.thing post
+func parseRedditPost(post *html.Node, maxFieldBytes int) SearchResult {
+	var result SearchResult
+	var titleHref string
+
+	// Find title link (a.title) and subreddit link (a.subreddit)
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.Data == "a" {
+			switch {
+			case hasClass(node, "title") && result.Title == "":
+				result.Title = extractBoundedText(node, maxFieldBytes)
+				titleHref = getAttr(node, "href")
+			case hasClass(node, "subreddit") && result.Snippet == "":
+				result.Snippet = extractBoundedText(node, maxFieldBytes)
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(post)
+
+	// The permalink identifies the post even when the title links elsewhere
+	permalink := getAttr(post, "data-permalink")
+	if permalink == "" && titleHref != "" && titleHref != "#" {
+		permalink = titleHref
+	}
+	result.Link = resolveRedditURL(permalink)
+
+	if result.Snippet == "" {
+		if subreddit := getAttr(post, "data-subreddit"); subreddit != "" {
+			result.Snippet = "r/" + subreddit
+		}
+	}
+
+	return result
+}
+
+// resolveRedditURL makes a listing link absolute against old.reddit.com
+func resolveRedditURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return redditBaseURL.ResolveReference(parsed).String()
+}
+
+// getAttr returns the value of the named attribute or an empty string
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseRedditListing(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/reddit_listing.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	expected := []SearchResult{
+		{
+			Title:    "Go 1.25 is released",
+			Link:     "https://old.reddit.com/r/golang/comments/1a2b3c/go_125_is_released/",
+			Snippet:  "r/golang",
+			Position: 1,
+		},
+		{
+			Title:    "How do you structure & organize large projects?",
+			Link:     "https://old.reddit.com/r/golang/comments/4d5e6f/how_do_you_structure_large_projects/",
+			Snippet:  "r/golang",
+			Position: 2,
+		},
+		{
+			Title:    "Why generics took so long",
+			Link:     "https://old.reddit.com/r/programming/comments/7g8h9i/why_generics_took_so_long/",
+			Snippet:  "r/programming",
+			Position: 3,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		maxResults int
+		expected   []SearchResult
+	}{
+		{
+			name:       "listing fixture",
+			input:      string(fixture),
+			maxResults: 10,
+			expected:   expected,
+		},
+		{
+			name:       "limit results",
+			input:      string(fixture),
+			maxResults: 2,
+			expected:   expected[:2],
+		},
+		{
+			name:       "empty input",
+			input:      "",
+			maxResults: 5,
+			expected:   []SearchResult{},
+		},
+		{
+			name:       "subreddit from data attribute",
+			input:      `<div class="thing link" data-subreddit="rust"><p class="title"><a class="title" href="/r/rust/comments/x/borrowck/">Borrowck</a></p></div>`,
+			maxResults: 5,
+			expected: []SearchResult{
+				{Title: "Borrowck", Link: "https://old.reddit.com/r/rust/comments/x/borrowck/", Snippet: "r/rust", Position: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ParseRedditListing(tt.input, tt.maxResults)
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("ParseRedditListing() failed\nExpected: %+v\nGot:      %+v", tt.expected, results)
+			}
+		})
+	}
+}
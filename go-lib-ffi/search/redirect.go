@@ -0,0 +1,202 @@
+package search
+
+import (
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("redirect_patterns")
+}
+
+// redirectPattern describes a redirect wrapper URL that carries
+// its real target in a query parameter
+type redirectPattern struct {
+	// host is a substring the wrapper host must contain; empty matches any host
+	host string
+	// path is a prefix the wrapper path must start with; empty matches any path
+	path string
+	// param is the query parameter holding the target URL
+	param string
+	// decode optionally transforms the parameter value into the target URL
+	decode func(string) string
+}
+
+// redirectPatterns lists the search engine redirect wrappers, most specific first
+var redirectPatterns = []redirectPattern{
+	{host: "duckduckgo.com", path: "/l/", param: "uddg"},
+	{host: "google.", path: "/url", param: "q"},
+	{host: "google.", path: "/url", param: "url"},
+	{host: "bing.com", path: "/ck/a", param: "u", decode: decodeBingTarget},
+}
+
+// genericRedirectPatterns lists query parameters that sites use for their own
+// redirect pages ("/out?url=..."). They match any host, so ordinary pages
+// such as "https://validator.w3.org/check?url=..." look the same; they are
+// only tried when RedirectOptions.Generic is set.
+var genericRedirectPatterns = []redirectPattern{
+	{param: "url"},
+	{param: "redirect"},
+	{param: "redirect_url"},
+}
+
+// RedirectOptions configures UnwrapRedirectWithOptions
+type RedirectOptions struct {
+	// Generic also unwraps ?url=, ?redirect= and ?redirect_url= parameters
+	// on any host. It is opt-in because many real pages take a URL in these
+	// parameters, and must not be used on search result links. Only the URL
+	// passed in is checked for them; nested levels are only unwrapped through
+	// engine wrappers and patterns added with RegisterRedirectPattern.
+	Generic bool `json:"generic"`
+
+	// MaxDepth is the number of nested wrappers peeled off.
+	// 0 means DefaultMaxRedirectDepth; a negative value disables unwrapping.
+	MaxDepth int `json:"max_depth"`
+}
+
+// Redirect wrappers added with RegisterRedirectPattern, checked before
+// the built-in redirectPatterns
+var (
+	customRedirectMu       sync.RWMutex
+	customRedirectPatterns []redirectPattern
+)
+
+// RegisterRedirectPattern teaches UnwrapRedirect, and so the search result
+// parsers, a new redirect wrapper: URLs whose host contains hostContains
+// carry their target in the query parameter paramName, e.g.
+// RegisterRedirectPattern("proxy.example", "to") for "https://proxy.example/go?to=...".
+// An empty hostContains matches any host, relative URLs included.
+// Registering the same pattern twice has no effect. It is safe to call
+// concurrently with parsing.
+func RegisterRedirectPattern(hostContains, paramName string) {
+	if paramName == "" {
+		return
+	}
+
+	pattern := redirectPattern{host: hostContains, param: paramName}
+
+	customRedirectMu.Lock()
+	defer customRedirectMu.Unlock()
+	for _, existing := range customRedirectPatterns {
+		if existing.host == pattern.host && existing.param == pattern.param {
+			return
+		}
+	}
+	customRedirectPatterns = append(customRedirectPatterns, pattern)
+}
+
+// DefaultMaxRedirectDepth is the number of nested redirect wrappers
+// UnwrapRedirect peels off before giving up
+const DefaultMaxRedirectDepth = 5
+
+// UnwrapRedirect returns the target of a known redirect wrapper URL
+// (DuckDuckGo, Google, Bing, or one added with RegisterRedirectPattern).
+// Nested wrappers are unwrapped up to DefaultMaxRedirectDepth levels.
+// Only absolute http(s) targets are accepted; any other URL is returned unchanged.
+func UnwrapRedirect(rawURL string) string {
+	return UnwrapRedirectWithOptions(rawURL, RedirectOptions{})
+}
+
+// UnwrapRedirectDepth unwraps nested redirect wrappers until it reaches a URL
+// that is not a redirect or has unwrapped maxDepth levels, and returns the
+// last successfully decoded URL. A maxDepth of 0 or less returns rawURL unchanged.
+func UnwrapRedirectDepth(rawURL string, maxDepth int) string {
+	if maxDepth <= 0 {
+		return rawURL
+	}
+	return UnwrapRedirectWithOptions(rawURL, RedirectOptions{MaxDepth: maxDepth})
+}
+
+// UnwrapRedirectWithOptions unwraps redirect wrappers like UnwrapRedirect
+// with the behaviors selected in opts
+func UnwrapRedirectWithOptions(rawURL string, opts RedirectOptions) string {
+	maxDepth := opts.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxRedirectDepth
+	}
+
+	current := rawURL
+	for depth := 0; depth < maxDepth; depth++ {
+		// A share link such as ".../share?url=..." behind an engine wrapper
+		// is the result itself, so generic patterns never apply past the top
+		target, ok := unwrapOnce(current, opts.Generic && depth == 0)
+		if !ok || target == current {
+			break
+		}
+		current = target
+	}
+	return current
+}
+
+// unwrapOnce extracts the target of rawURL if it matches a redirect pattern,
+// trying the generic patterns too when generic is set
+func unwrapOnce(rawURL string, generic bool) (string, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return "", false
+	}
+
+	customRedirectMu.RLock()
+	patterns := slices.Concat(customRedirectPatterns, redirectPatterns)
+	customRedirectMu.RUnlock()
+	if generic {
+		patterns = append(patterns, genericRedirectPatterns...)
+	}
+
+	query := parsed.Query()
+	for _, pattern := range patterns {
+		if !strings.Contains(parsed.Host, pattern.host) || !strings.HasPrefix(parsed.Path, pattern.path) {
+			continue
+		}
+
+		target := query.Get(pattern.param)
+		if target == "" {
+			continue
+		}
+
+		// Some wrappers encode the target twice
+		if !strings.Contains(target, "://") {
+			if decoded, err := url.QueryUnescape(target); err == nil {
+				target = decoded
+			}
+		}
+		if pattern.decode != nil {
+			target = pattern.decode(target)
+		}
+
+		if isAbsoluteHTTPURL(target) {
+			return target, true
+		}
+	}
+
+	return "", false
+}
+
+// decodeBingTarget decodes Bing's "a1" + base64url encoded target URLs
+func decodeBingTarget(value string) string {
+	encoded, ok := strings.CutPrefix(value, "a1")
+	if !ok {
+		return value
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return value
+	}
+	return string(decoded)
+}
+
+// isAbsoluteHTTPURL reports whether rawURL is an http or https URL with a host
+func isAbsoluteHTTPURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	return (scheme == "http" || scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,275 @@
+package search
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestUnwrapRedirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     RedirectOptions
+		expected string
+	}{
+		{
+			name:     "duckduckgo",
+			input:    "https://duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fpath%3Fq%3D123&rut=abc",
+			expected: "https://example.com/path?q=123",
+		},
+		{
+			name:     "duckduckgo protocol relative",
+			input:    "//duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2F",
+			expected: "https://example.com/",
+		},
+		{
+			name:     "google q param",
+			input:    "https://www.google.com/url?q=https://example.com/article&sa=U&ved=xyz",
+			expected: "https://example.com/article",
+		},
+		{
+			name:     "google url param on country domain",
+			input:    "https://www.google.co.uk/url?sa=t&url=https%3A%2F%2Fexample.org%2F",
+			expected: "https://example.org/",
+		},
+		{
+			name:     "bing base64 target",
+			input:    "https://www.bing.com/ck/a?!&&p=abc&ptn=3&u=a1aHR0cHM6Ly9leGFtcGxlLmNvbS9iaW5n&ntb=1",
+			expected: "https://example.com/bing",
+		},
+		{
+			name:     "generic url param",
+			input:    "https://news.example.net/out?url=https%3A%2F%2Ftarget.example%2Fstory",
+			opts:     RedirectOptions{Generic: true},
+			expected: "https://target.example/story",
+		},
+		{
+			name:     "generic redirect param",
+			input:    "https://example.net/leave?redirect=http://target.example/",
+			opts:     RedirectOptions{Generic: true},
+			expected: "http://target.example/",
+		},
+		{
+			name:     "generic params ignored by default",
+			input:    "https://validator.w3.org/check?url=https://example.com",
+			expected: "https://validator.w3.org/check?url=https://example.com",
+		},
+		{
+			name:     "non-http target is not followed",
+			input:    "https://example.net/out?url=javascript:alert(1)",
+			opts:     RedirectOptions{Generic: true},
+			expected: "https://example.net/out?url=javascript:alert(1)",
+		},
+		{
+			name:     "passthrough",
+			input:    "https://example.com/page?q=search",
+			expected: "https://example.com/page?q=search",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnwrapRedirectWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("UnwrapRedirectWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnwrapRedirectNested(t *testing.T) {
+	// A DuckDuckGo redirect whose target is itself a Google redirect
+	doubly := "https://duckduckgo.com/l/?uddg=" +
+		"https%3A%2F%2Fwww.google.com%2Furl%3Fq%3Dhttps%253A%252F%252Fexample.com%252Ffinal"
+
+	tests := []struct {
+		name     string
+		input    string
+		maxDepth int
+		expected string
+	}{
+		{
+			name:     "doubly wrapped",
+			input:    doubly,
+			maxDepth: DefaultMaxRedirectDepth,
+			expected: "https://example.com/final",
+		},
+		{
+			name:     "depth limit stops early",
+			input:    doubly,
+			maxDepth: 1,
+			expected: "https://www.google.com/url?q=https%3A%2F%2Fexample.com%2Ffinal",
+		},
+		{
+			name:     "zero depth is a no-op",
+			input:    doubly,
+			maxDepth: 0,
+			expected: doubly,
+		},
+		{
+			name:     "malformed inner target stops at last good URL",
+			input:    "https://duckduckgo.com/l/?uddg=https%3A%2F%2Fwww.google.com%2Furl%3Fq%3D%25zz",
+			maxDepth: DefaultMaxRedirectDepth,
+			expected: "https://www.google.com/url?q=%zz",
+		},
+		{
+			name:     "generic wrapper not followed",
+			input:    "https://example.net/out?url=https%3A%2F%2Fexample.net%2Fout%3Furl%3Dhttps%253A%252F%252Fexample.net%252Fout",
+			maxDepth: DefaultMaxRedirectDepth,
+			expected: "https://example.net/out?url=https%3A%2F%2Fexample.net%2Fout%3Furl%3Dhttps%253A%252F%252Fexample.net%252Fout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnwrapRedirectDepth(tt.input, tt.maxDepth)
+			if result != tt.expected {
+				t.Errorf("UnwrapRedirectDepth() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+
+	if UnwrapRedirect(doubly) != "https://example.com/final" {
+		t.Errorf("UnwrapRedirect() should unwrap nested redirects by default")
+	}
+}
+
+func TestUnwrapRedirectNestedGeneric(t *testing.T) {
+	// A DuckDuckGo redirect whose target is a share page taking a ?url=
+	share := "https://www.linkedin.com/sharing/share-offsite/?url=https://example.com/article"
+	wrappedShare := "https://duckduckgo.com/l/?uddg=" + url.QueryEscape(share)
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     RedirectOptions
+		expected string
+	}{
+		{
+			name:     "share page behind engine wrapper kept",
+			input:    wrappedShare,
+			expected: share,
+		},
+		{
+			name:     "generic patterns not applied past the top level",
+			input:    wrappedShare,
+			opts:     RedirectOptions{Generic: true},
+			expected: share,
+		},
+		{
+			name:     "engine wrapper behind generic wrapper unwrapped",
+			input:    "https://example.net/out?url=" + url.QueryEscape("https://www.google.com/url?q=https://example.com/final"),
+			opts:     RedirectOptions{Generic: true},
+			expected: "https://example.com/final",
+		},
+		{
+			name:     "generic wrapper chain stops after one level",
+			input:    "https://example.net/out?url=https%3A%2F%2Fexample.net%2Fout%3Furl%3Dhttps%253A%252F%252Fexample.net%252Fout",
+			opts:     RedirectOptions{Generic: true},
+			expected: "https://example.net/out?url=https%3A%2F%2Fexample.net%2Fout",
+		},
+		{
+			name:     "negative depth disables unwrapping",
+			input:    wrappedShare,
+			opts:     RedirectOptions{MaxDepth: -1},
+			expected: wrappedShare,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnwrapRedirectWithOptions(tt.input, tt.opts)
+			if result != tt.expected {
+				t.Errorf("UnwrapRedirectWithOptions() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+
+	if result := cleanDuckDuckGoURL(wrappedShare); result != share {
+		t.Errorf("cleanDuckDuckGoURL() failed\nInput:    %s\nExpected: %s\nGot:      %s", wrappedShare, share, result)
+	}
+}
+
+func TestRegisterRedirectPattern(t *testing.T) {
+	RegisterRedirectPattern("proxy.example", "to")
+	RegisterRedirectPattern("proxy.example", "to")
+	RegisterRedirectPattern("", "dest")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "registered host and parameter",
+			input:    "https://proxy.example/go?to=https%3A%2F%2Fexample.com%2Fpage",
+			expected: "https://example.com/page",
+		},
+		{
+			name:     "parameter on another host left alone",
+			input:    "https://other.example/go?to=https%3A%2F%2Fexample.com%2Fpage",
+			expected: "https://other.example/go?to=https%3A%2F%2Fexample.com%2Fpage",
+		},
+		{
+			name:     "any host, relative URL included",
+			input:    "/jump?dest=https%3A%2F%2Fexample.org%2F",
+			expected: "https://example.org/",
+		},
+		{
+			name:     "built-in patterns still apply",
+			input:    "https://duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2F",
+			expected: "https://example.com/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnwrapRedirect(tt.input)
+			if result != tt.expected {
+				t.Errorf("UnwrapRedirect() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+
+	// Duplicate registrations are ignored
+	customRedirectMu.RLock()
+	count := 0
+	for _, pattern := range customRedirectPatterns {
+		if pattern.host == "proxy.example" && pattern.param == "to" {
+			count++
+		}
+	}
+	customRedirectMu.RUnlock()
+	if count != 1 {
+		t.Errorf("RegisterRedirectPattern() failed\nExpected: 1 registered pattern\nGot:      %d", count)
+	}
+
+	// The parser unwraps result links with the registered pattern
+	input := `<div class="result"><a class="result__a" href="https://proxy.example/go?to=https%3A%2F%2Fgo.dev%2Fdoc%2F">Documentation</a></div>`
+	results := ParseSearchResults(input, 10)
+	if len(results) != 1 || results[0].Link != "https://go.dev/doc/" {
+		t.Errorf("ParseSearchResults() failed\nInput:    %s\nExpected: link https://go.dev/doc/\nGot:      %+v", input, results)
+	}
+}
+
+func TestRegisterRedirectPatternConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			RegisterRedirectPattern("concurrent.example", "p"+strconv.Itoa(g))
+		}(g)
+		go func() {
+			defer wg.Done()
+			UnwrapRedirect("https://concurrent.example/r?p0=https%3A%2F%2Fexample.com%2F")
+		}()
+	}
+	wg.Wait()
+
+	if result := UnwrapRedirect("https://concurrent.example/r?p7=https%3A%2F%2Fexample.com%2F"); result != "https://example.com/" {
+		t.Errorf("UnwrapRedirect() failed\nExpected: https://example.com/\nGot:      %s", result)
+	}
+}
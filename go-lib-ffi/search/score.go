@@ -0,0 +1,76 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// titleTermWeight and snippetTermWeight score a query term found in
+	// the title or the snippet of a result
+	titleTermWeight   = 3.0
+	snippetTermWeight = 1.0
+
+	// snippetLengthWeight is the bonus for a snippet of at least
+	// fullSnippetChars characters; shorter snippets get a share of it
+	snippetLengthWeight = 0.5
+	fullSnippetChars    = 160
+)
+
+// scoreResult rates how well result matches the query terms. Every term
+// found in the title adds titleTermWeight and every term found in the snippet
+// adds snippetTermWeight, averaged over the terms; a descriptive snippet adds
+// up to snippetLengthWeight. Scores range from 0 to 4.5, rounded to 3 decimals.
+func scoreResult(result SearchResult, terms []string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+
+	titleWords := wordSet(result.Title)
+	snippetWords := wordSet(result.Snippet)
+
+	var matches float64
+	for _, term := range terms {
+		if titleWords[term] {
+			matches += titleTermWeight
+		}
+		if snippetWords[term] {
+			matches += snippetTermWeight
+		}
+	}
+
+	length := float64(min(utf8.RuneCountInString(result.Snippet), fullSnippetChars)) / fullSnippetChars
+	score := matches/float64(len(terms)) + snippetLengthWeight*length
+	return math.Round(score*1000) / 1000
+}
+
+// queryTerms returns the distinct lowercase words of query
+func queryTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range splitWords(query) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// wordSet returns the lowercase words of s as a set
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range splitWords(s) {
+		set[word] = true
+	}
+	return set
+}
+
+// splitWords splits s into lowercase words of letters and digits
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,104 @@
+package search
+
+import "testing"
+
+func TestScoreResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   SearchResult
+		query    string
+		expected float64
+	}{
+		{
+			name:     "terms in title and snippet",
+			result:   SearchResult{Title: "Go Tutorial", Snippet: "Learn Go quickly"},
+			query:    "go tutorial",
+			expected: 3.55,
+		},
+		{
+			name:     "term in snippet only",
+			result:   SearchResult{Title: "Programming", Snippet: "A short tutorial"},
+			query:    "tutorial",
+			expected: 1.05,
+		},
+		{
+			name:     "no matching terms",
+			result:   SearchResult{Title: "Cooking", Snippet: ""},
+			query:    "golang",
+			expected: 0,
+		},
+		{
+			name:     "whole words only and case-insensitive",
+			result:   SearchResult{Title: "GOLANG news", Snippet: ""},
+			query:    "Go golang",
+			expected: 1.5,
+		},
+		{
+			name:     "empty query",
+			result:   SearchResult{Title: "Go", Snippet: "Go"},
+			query:    "",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scoreResult(tt.result, queryTerms(tt.query))
+			if result != tt.expected {
+				t.Errorf("scoreResult() failed\nInput:    %+v, %q\nExpected: %v\nGot:      %v", tt.result, tt.query, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseSearchResultsSortByScore(t *testing.T) {
+	input := `
+	<div class="result"><a class="result__a" href="https://a.example">Cooking recipes</a>
+		<a class="result__snippet">Dinner ideas</a></div>
+	<div class="result"><a class="result__a" href="https://b.example">Rust book</a>
+		<a class="result__snippet">Learn the Go alternative</a></div>
+	<div class="result"><a class="result__a" href="https://c.example">The Go tutorial</a>
+		<a class="result__snippet">A tour of Go</a></div>
+	`
+
+	t.Run("page order by default", func(t *testing.T) {
+		results := ParseSearchResultsWithOptions(input, 10, SearchOptions{Query: "go tutorial"})
+		links := []string{"https://a.example", "https://b.example", "https://c.example"}
+		for i, result := range results {
+			if result.Link != links[i] || result.Position != i+1 {
+				t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %s at position %d\nGot:      %s at position %d", links[i], i+1, result.Link, result.Position)
+			}
+		}
+		if results[2].Score <= results[1].Score || results[1].Score <= results[0].Score {
+			t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: increasing scores\nGot:      %v, %v, %v", results[0].Score, results[1].Score, results[2].Score)
+		}
+	})
+
+	t.Run("sorted by score", func(t *testing.T) {
+		results := ParseSearchResultsWithOptions(input, 10, SearchOptions{Query: "go tutorial", SortByScore: true})
+		expected := []struct {
+			link     string
+			position int
+		}{
+			{"https://c.example", 3},
+			{"https://b.example", 2},
+			{"https://a.example", 1},
+		}
+		if len(results) != len(expected) {
+			t.Fatalf("ParseSearchResultsWithOptions() failed\nExpected: %d results\nGot:      %d", len(expected), len(results))
+		}
+		for i, want := range expected {
+			if results[i].Link != want.link || results[i].Position != want.position {
+				t.Errorf("ParseSearchResultsWithOptions() failed\nExpected: %s at position %d\nGot:      %s at position %d", want.link, want.position, results[i].Link, results[i].Position)
+			}
+		}
+	})
+
+	t.Run("no scores without query", func(t *testing.T) {
+		for _, result := range ParseSearchResults(input, 10) {
+			if result.Score != 0 {
+				t.Errorf("ParseSearchResults() failed\nExpected: score 0\nGot:      %v", result.Score)
+			}
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"net/url"
+	"strings"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("tracking_params")
+}
+
+// DefaultTrackingParams lists query parameters removed by CleanTrackingParams.
+// Entries ending in "*" match any parameter with that prefix.
+var DefaultTrackingParams = []string{
+	"utm_*",
+	"mc_*",
+	"gclid",
+	"gclsrc",
+	"dclid",
+	"fbclid",
+	"msclkid",
+	"yclid",
+	"igshid",
+	"mkt_tok",
+	"_hsenc",
+	"_hsmi",
+	"ref",
+	"ref_src",
+}
+
+// CleanTrackingParams removes known tracking parameters (DefaultTrackingParams)
+// from a URL. The remaining query parameters keep their order and encoding,
+// and the fragment is preserved. Unparseable URLs are returned unchanged.
+func CleanTrackingParams(rawURL string) string {
+	return CleanTrackingParamsWith(rawURL, DefaultTrackingParams)
+}
+
+// CleanTrackingParamsWith removes the query parameters matching blocklist from a URL.
+// Matching is case-insensitive; entries ending in "*" match by prefix.
+func CleanTrackingParamsWith(rawURL string, blocklist []string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, _, _ := strings.Cut(pair, "=")
+		if decoded, err := url.QueryUnescape(key); err == nil {
+			key = decoded
+		}
+		if !isBlockedParam(key, blocklist) {
+			kept = append(kept, pair)
+		}
+	}
+
+	parsed.RawQuery = strings.Join(kept, "&")
+	if parsed.RawQuery == "" {
+		parsed.ForceQuery = false
+	}
+	return parsed.String()
+}
+
+// isBlockedParam reports whether key matches an entry of blocklist
+func isBlockedParam(key string, blocklist []string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range blocklist {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package search
+
+import "testing"
+
+func TestCleanTrackingParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no query",
+			input:    "https://example.com/page",
+			expected: "https://example.com/page",
+		},
+		{
+			name:     "only tracking params",
+			input:    "https://example.com/page?utm_source=news&utm_medium=email&fbclid=abc",
+			expected: "https://example.com/page",
+		},
+		{
+			name:     "mixed tracking and real params keep order",
+			input:    "https://example.com/search?q=go+lang&utm_campaign=x&page=2&gclid=123&sort=new",
+			expected: "https://example.com/search?q=go+lang&page=2&sort=new",
+		},
+		{
+			name:     "fragment preserved",
+			input:    "https://example.com/doc?id=7&mc_cid=abc&mc_eid=def#section-2",
+			expected: "https://example.com/doc?id=7#section-2",
+		},
+		{
+			name:     "case insensitive keys",
+			input:    "https://example.com/?UTM_Source=x&Ref=home&id=1",
+			expected: "https://example.com/?id=1",
+		},
+		{
+			name:     "similar names are kept",
+			input:    "https://example.com/?reference=1&refresh=true",
+			expected: "https://example.com/?reference=1&refresh=true",
+		},
+		{
+			name:     "encoded values untouched",
+			input:    "https://example.com/?q=a%26b%3Dc&utm_term=x",
+			expected: "https://example.com/?q=a%26b%3Dc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanTrackingParams(tt.input)
+			if result != tt.expected {
+				t.Errorf("CleanTrackingParams() failed\nInput:    %s\nExpected: %s\nGot:      %s", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCleanTrackingParamsWithCustomBlocklist(t *testing.T) {
+	input := "https://example.com/?session=abc&utm_source=x&id=1"
+	expected := "https://example.com/?utm_source=x&id=1"
+
+	result := CleanTrackingParamsWith(input, []string{"session"})
+	if result != expected {
+		t.Errorf("CleanTrackingParamsWith() failed\nInput:    %s\nExpected: %s\nGot:      %s", input, expected, result)
+	}
+}
@@ -0,0 +1,69 @@
+package textutil
+
+import (
+	"sync"
+	"unsafe"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("string_arenas")
+}
+
+// Arenas tracks groups of allocations made for a foreign caller, so a whole
+// group can be released with one call instead of freeing every allocation.
+// Each arena is identified by an integer handle; 0 is never a valid handle.
+// Arenas is safe for concurrent use.
+type Arenas struct {
+	mu     sync.Mutex
+	next   int
+	arenas map[int][]unsafe.Pointer
+	free   func(unsafe.Pointer)
+}
+
+// NewArenas returns an empty registry releasing allocations with free
+func NewArenas(free func(unsafe.Pointer)) *Arenas {
+	return &Arenas{arenas: make(map[int][]unsafe.Pointer), free: free}
+}
+
+// New creates an empty arena and returns its handle
+func (a *Arenas) New() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.next++
+	a.arenas[a.next] = nil
+	return a.next
+}
+
+// Track records p as owned by the arena with the given handle.
+// It reports false, leaving p to the caller, if the arena does not exist.
+func (a *Arenas) Track(handle int, p unsafe.Pointer) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pointers, ok := a.arenas[handle]
+	if !ok {
+		return false
+	}
+	a.arenas[handle] = append(pointers, p)
+	return true
+}
+
+// Free releases every allocation of the arena and forgets its handle.
+// It returns the number of allocations released, 0 for unknown handles.
+func (a *Arenas) Free(handle int) int {
+	a.mu.Lock()
+	pointers, ok := a.arenas[handle]
+	delete(a.arenas, handle)
+	a.mu.Unlock()
+
+	if !ok {
+		return 0
+	}
+	for _, p := range pointers {
+		a.free(p)
+	}
+	return len(pointers)
+}
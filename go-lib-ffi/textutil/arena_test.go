@@ -0,0 +1,80 @@
+package textutil
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestArenas(t *testing.T) {
+	freed := map[unsafe.Pointer]int{}
+	arenas := NewArenas(func(p unsafe.Pointer) { freed[p]++ })
+
+	first, second := arenas.New(), arenas.New()
+	if first == 0 || second == 0 || first == second {
+		t.Fatalf("New() failed\nExpected: distinct non-zero handles\nGot:      %d and %d", first, second)
+	}
+
+	const count = 10000
+	var allocated []unsafe.Pointer
+	for i := 0; i < count; i++ {
+		p := unsafe.Pointer(new(int))
+		if !arenas.Track(first, p) {
+			t.Fatalf("Track() failed\nExpected: true for arena %d\nGot:      false", first)
+		}
+		allocated = append(allocated, p)
+	}
+	kept := unsafe.Pointer(new(int))
+	arenas.Track(second, kept)
+
+	if n := arenas.Free(first); n != count {
+		t.Errorf("Free() failed\nExpected: %d allocations released\nGot:      %d", count, n)
+	}
+	for _, p := range allocated {
+		if freed[p] != 1 {
+			t.Fatalf("Free() failed\nExpected: every allocation released once\nGot:      %d releases of %p", freed[p], p)
+		}
+	}
+	if freed[kept] != 0 {
+		t.Errorf("Free() failed\nExpected: allocations of other arenas kept\nGot:      %d releases", freed[kept])
+	}
+
+	// A freed handle is gone and cannot be reused by mistake
+	if arenas.Track(first, unsafe.Pointer(new(int))) {
+		t.Errorf("Track() failed\nExpected: false for a freed arena\nGot:      true")
+	}
+	if n := arenas.Free(first); n != 0 {
+		t.Errorf("Free() failed\nExpected: 0 for a freed arena\nGot:      %d", n)
+	}
+	if n := arenas.Free(0); n != 0 {
+		t.Errorf("Free() failed\nExpected: 0 for an unknown arena\nGot:      %d", n)
+	}
+}
+
+func TestArenasConcurrentUse(t *testing.T) {
+	var mu sync.Mutex
+	freed := 0
+	arenas := NewArenas(func(unsafe.Pointer) {
+		mu.Lock()
+		freed++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handle := arenas.New()
+			for i := 0; i < 500; i++ {
+				arenas.Track(handle, unsafe.Pointer(new(int)))
+			}
+			arenas.Free(handle)
+		}()
+	}
+	wg.Wait()
+
+	if freed != 8*500 {
+		t.Errorf("Free() failed\nExpected: %d allocations released\nGot:      %d", 8*500, freed)
+	}
+}
@@ -0,0 +1,36 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StripInvisible removes characters that take no space in rendered text
+// but break tokenization and search: zero-width spaces and joiners
+// (U+200B-U+200D), word joiners (U+2060-U+2064), bidirectional marks and
+// embeddings (U+200E, U+200F, U+202A-U+202E), byte order marks (U+FEFF)
+// and control characters. Whitespace, including tabs and line breaks,
+// is kept.
+func StripInvisible(s string) string {
+	if strings.IndexFunc(s, isInvisible) < 0 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if isInvisible(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// isInvisible reports whether StripInvisible removes r
+func isInvisible(r rune) bool {
+	switch {
+	case r >= '\u200b' && r <= '\u200f',
+		r >= '\u202a' && r <= '\u202e',
+		r >= '\u2060' && r <= '\u2064',
+		r == '\ufeff':
+		return true
+	}
+	return unicode.IsControl(r) && !unicode.IsSpace(r)
+}
@@ -0,0 +1,51 @@
+package textutil
+
+import "testing"
+
+func TestStripInvisible(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text unchanged",
+			input:    "Hello, world",
+			expected: "Hello, world",
+		},
+		{
+			name:     "zero-width space joins word",
+			input:    "token\u200bization",
+			expected: "tokenization",
+		},
+		{
+			name:     "joiners and byte order mark",
+			input:    "\ufeffa\u200cb\u200dc\u2060d",
+			expected: "abcd",
+		},
+		{
+			name:     "bidi marks",
+			input:    "\u202bשלום\u202c \u200eok",
+			expected: "שלום ok",
+		},
+		{
+			name:     "control characters",
+			input:    "bell\x07 null\x00 escape\x1b[0m del\x7f c1\u0085x",
+			expected: "bell null escape[0m del c1\u0085x",
+		},
+		{
+			name:     "whitespace kept",
+			input:    "a\tb\nc\r\nd\ve\ff",
+			expected: "a\tb\nc\r\nd\ve\ff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StripInvisible(tt.input)
+			if result != tt.expected {
+				t.Errorf("StripInvisible() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
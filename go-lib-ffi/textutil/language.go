@@ -0,0 +1,109 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minStopwordHits is the number of stopword matches needed before
+// GuessLanguage trusts a stopword-based guess
+const minStopwordHits = 2
+
+// GuessLanguage returns a best-effort ISO 639-1 code for text, or an empty
+// string when the language cannot be determined.
+// Texts dominated by a distinctive script (CJK, Hangul, Cyrillic, Arabic, ...)
+// are classified by script; Latin-script texts are classified by counting
+// stopwords of the bundled languages.
+func GuessLanguage(text string) string {
+	if lang := guessLanguageByScript(text); lang != "" {
+		return lang
+	}
+	return guessLanguageByStopwords(text)
+}
+
+// guessLanguageByScript classifies text whose letters are mostly
+// from a script used by a single well-known language
+func guessLanguageByScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		}
+	}
+
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese mixes kana with Han characters, so any meaningful
+	// amount of kana decides between Japanese and Chinese
+	if counts["ja"] > 0 && counts["ja"]+counts["zh"] > letters/2 {
+		return "ja"
+	}
+
+	for lang, count := range counts {
+		if count > letters/2 {
+			return lang
+		}
+	}
+	return ""
+}
+
+// guessLanguageByStopwords returns the bundled language with the most
+// stopword matches, or an empty string on a tie or too few matches
+func guessLanguageByStopwords(text string) string {
+	scores := make(map[string]int)
+	for _, word := range words(text) {
+		for lang, set := range stopwords {
+			if set[word] {
+				scores[lang]++
+			}
+		}
+	}
+
+	best, bestScore, tied := "", 0, false
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = lang, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+
+	if bestScore < minStopwordHits || tied {
+		return ""
+	}
+	return best
+}
+
+// words splits text into lowercase words, dropping punctuation
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
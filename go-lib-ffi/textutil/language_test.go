@@ -0,0 +1,66 @@
+package textutil
+
+import "testing"
+
+func TestGuessLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "english",
+			input:    "The quick brown fox jumps over the lazy dog and runs into the forest.",
+			expected: "en",
+		},
+		{
+			name:     "french",
+			input:    "Le chat est sur la table et il dort dans le salon avec les enfants.",
+			expected: "fr",
+		},
+		{
+			name:     "german",
+			input:    "Der Hund ist nicht in dem Haus, und die Katze schläft auf dem Sofa.",
+			expected: "de",
+		},
+		{
+			name:     "spanish",
+			input:    "El perro y el gato son muy amigos, pero los niños no lo saben.",
+			expected: "es",
+		},
+		{
+			name:     "japanese",
+			input:    "これは日本語のテキストです。",
+			expected: "ja",
+		},
+		{
+			name:     "chinese",
+			input:    "这是一个中文句子。",
+			expected: "zh",
+		},
+		{
+			name:     "russian",
+			input:    "Это предложение на русском языке.",
+			expected: "ru",
+		},
+		{
+			name:     "too little text",
+			input:    "Hello",
+			expected: "",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GuessLanguage(tt.input)
+			if result != tt.expected {
+				t.Errorf("GuessLanguage() failed\nInput:    %s\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
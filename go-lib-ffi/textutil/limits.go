@@ -0,0 +1,99 @@
+package textutil
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Error codes reported when an input exceeds its Limits
+const (
+	CodeInputTooLarge = "input_too_large"
+	CodeTooManyNodes  = "too_many_nodes"
+)
+
+// LimitError reports an input rejected by Limits.Check
+type LimitError struct {
+	// Code is CodeInputTooLarge or CodeTooManyNodes
+	Code string
+
+	// Limit is the configured limit that was exceeded
+	Limit int
+}
+
+func (e *LimitError) Error() string {
+	switch e.Code {
+	case CodeInputTooLarge:
+		return "input exceeds the size limit"
+	case CodeTooManyNodes:
+		return "document exceeds the node limit"
+	}
+	return "input exceeds a limit"
+}
+
+// Is matches any LimitError with the same code, so errors.Is(err, ErrTooManyNodes)
+// holds whatever the configured limit
+func (e *LimitError) Is(target error) bool {
+	t, ok := target.(*LimitError)
+	return ok && t.Code == e.Code
+}
+
+// Sentinel errors for errors.Is checks against Limits.Check
+var (
+	ErrInputTooLarge = &LimitError{Code: CodeInputTooLarge}
+	ErrTooManyNodes  = &LimitError{Code: CodeTooManyNodes}
+)
+
+// Limits bounds the work spent on untrusted HTML before it is parsed.
+// A zero field disables that limit, so the zero value accepts everything.
+type Limits struct {
+	// MaxInputBytes rejects inputs longer than this many bytes
+	MaxInputBytes int `json:"max_input_bytes"`
+
+	// MaxNodes rejects documents with more elements, text runs and comments
+	// than this. Nodes are counted with a streaming tokenizer, so the
+	// check never builds the tree and stops as soon as the limit is passed.
+	MaxNodes int `json:"max_nodes"`
+}
+
+// Check returns a *LimitError if htmlStr exceeds l, or nil if it may be parsed
+func (l Limits) Check(htmlStr string) error {
+	if l.MaxInputBytes > 0 && len(htmlStr) > l.MaxInputBytes {
+		return &LimitError{Code: CodeInputTooLarge, Limit: l.MaxInputBytes}
+	}
+
+	if l.MaxNodes > 0 && countNodes(htmlStr, l.MaxNodes) > l.MaxNodes {
+		return &LimitError{Code: CodeTooManyNodes, Limit: l.MaxNodes}
+	}
+
+	return nil
+}
+
+// LimitErrorCode returns the code of a *LimitError in err's chain,
+// or an empty string if err is not a limit error
+func LimitErrorCode(err error) string {
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Code
+	}
+	return ""
+}
+
+// countNodes counts the nodes html.Parse would create for the tags, text and
+// comments in htmlStr, stopping once the count passes max
+func countNodes(htmlStr string, max int) int {
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlStr))
+	count := 0
+
+	for count <= max {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return count
+		case html.StartTagToken, html.SelfClosingTagToken, html.TextToken, html.CommentToken:
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,80 @@
+package textutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		limits   Limits
+		expected string
+	}{
+		{
+			name:     "zero limits accept everything",
+			input:    strings.Repeat("<b>x</b>", 1000),
+			limits:   Limits{},
+			expected: "",
+		},
+		{
+			name:     "input within size limit",
+			input:    "<p>hello</p>",
+			limits:   Limits{MaxInputBytes: 12},
+			expected: "",
+		},
+		{
+			name:     "input over size limit",
+			input:    "<p>hello</p>",
+			limits:   Limits{MaxInputBytes: 11},
+			expected: CodeInputTooLarge,
+		},
+		{
+			name:     "nodes within limit",
+			input:    "<div><p>a</p><br/><!-- c --></div>",
+			limits:   Limits{MaxNodes: 5},
+			expected: "",
+		},
+		{
+			name:     "nodes over limit",
+			input:    "<div><p>a</p><br/><!-- c --></div>",
+			limits:   Limits{MaxNodes: 4},
+			expected: CodeTooManyNodes,
+		},
+		{
+			name:     "end tags are not counted",
+			input:    "<i></i><i></i>",
+			limits:   Limits{MaxNodes: 2},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LimitErrorCode(tt.limits.Check(tt.input))
+			if result != tt.expected {
+				t.Errorf("Limits.Check() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLimitErrorIs(t *testing.T) {
+	err := Limits{MaxNodes: 10}.Check(strings.Repeat("<span>", 100))
+	if !errors.Is(err, ErrTooManyNodes) {
+		t.Errorf("Limits.Check() failed\nExpected: ErrTooManyNodes\nGot:      %v", err)
+	}
+	if errors.Is(err, ErrInputTooLarge) {
+		t.Errorf("Limits.Check() failed\nExpected: not ErrInputTooLarge\nGot:      %v", err)
+	}
+}
+
+func TestCountNodesStopsEarly(t *testing.T) {
+	// A synthetic bomb of a million tags must be rejected after max+1 tokens
+	input := strings.Repeat("<a>", 1000000)
+	if count := countNodes(input, 100); count != 101 {
+		t.Errorf("countNodes() failed\nExpected: 101\nGot:      %d", count)
+	}
+}
@@ -0,0 +1,32 @@
+package textutil
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBufferSize caps the capacity of buffers returned to the pool so a
+// single huge document does not pin its memory for the life of the process
+const maxPooledBufferSize = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// GetBuffer returns an empty buffer from the shared pool.
+// Callers must hand it back with PutBuffer once its contents have been copied out.
+func GetBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// PutBuffer resets buf and returns it to the shared pool.
+// Oversized buffers are dropped instead of being retained.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,61 @@
+package textutil
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestBufferPoolReset(t *testing.T) {
+	buf := GetBuffer()
+	buf.WriteString("leftover")
+	PutBuffer(buf)
+
+	for i := 0; i < 10; i++ {
+		buf := GetBuffer()
+		if buf.Len() != 0 {
+			t.Fatalf("GetBuffer() returned a non-empty buffer: %q", buf.String())
+		}
+		PutBuffer(buf)
+	}
+}
+
+func TestBufferPoolDropsOversizedBuffers(t *testing.T) {
+	oversized := GetBuffer()
+	oversized.Grow(maxPooledBufferSize + 1)
+	oversized.WriteString("kept")
+	PutBuffer(oversized)
+
+	if oversized.String() != "kept" {
+		t.Errorf("PutBuffer() failed\nExpected: oversized buffer left untouched\nGot:      %q", oversized.String())
+	}
+
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if buf == oversized {
+		t.Fatalf("GetBuffer() failed\nExpected: a buffer other than the dropped oversized one\nGot:      the same buffer")
+	}
+	if buf.Cap() > maxPooledBufferSize {
+		t.Errorf("GetBuffer() failed\nExpected: capacity at most %d\nGot:      %d", maxPooledBufferSize, buf.Cap())
+	}
+}
+
+func TestBufferPoolConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				want := strconv.Itoa(g) + "-" + strconv.Itoa(i)
+				buf := GetBuffer()
+				buf.WriteString(want)
+				if got := buf.String(); got != want {
+					t.Errorf("buffer shared between goroutines: got %q, want %q", got, want)
+				}
+				PutBuffer(buf)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,51 @@
+package textutil
+
+import (
+	"time"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("process_with_stats")
+}
+
+// CodeUnknownOperation is reported by MeasureOp for operation names it
+// cannot run
+const CodeUnknownOperation = "unknown_operation"
+
+// OpStats describes a single run of a string operation
+type OpStats struct {
+	// Result is the output of the operation
+	Result string `json:"result"`
+
+	// InputBytes and OutputBytes are the sizes of the input and the result
+	InputBytes  int `json:"input_bytes"`
+	OutputBytes int `json:"output_bytes"`
+
+	// DurationMicros is the wall-clock time spent in the operation
+	DurationMicros int64 `json:"duration_us"`
+
+	// Error is CodeUnknownOperation when the operation does not exist
+	Error string `json:"error,omitempty"`
+}
+
+// MeasureOp runs the operation named op from ops on input and reports its
+// result together with the input and output sizes and the time it took.
+// An unknown op reports CodeUnknownOperation without a result.
+func MeasureOp(ops map[string]func(string) string, op string, input string) OpStats {
+	stats := OpStats{InputBytes: len(input)}
+
+	fn, ok := ops[op]
+	if !ok {
+		stats.Error = CodeUnknownOperation
+		return stats
+	}
+
+	start := time.Now()
+	stats.Result = fn(input)
+	stats.DurationMicros = time.Since(start).Microseconds()
+	stats.OutputBytes = len(stats.Result)
+
+	return stats
+}
@@ -0,0 +1,80 @@
+package textutil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMeasureOp(t *testing.T) {
+	ops := map[string]func(string) string{
+		"upper": strings.ToUpper,
+		"slow": func(s string) string {
+			time.Sleep(2 * time.Millisecond)
+			return s[:2]
+		},
+	}
+
+	tests := []struct {
+		name        string
+		op          string
+		input       string
+		expected    OpStats
+		minDuration int64
+	}{
+		{
+			name:     "result and sizes",
+			op:       "upper",
+			input:    "héllo",
+			expected: OpStats{Result: "HÉLLO", InputBytes: 6, OutputBytes: 6},
+		},
+		{
+			name:        "duration",
+			op:          "slow",
+			input:       "abcdef",
+			expected:    OpStats{Result: "ab", InputBytes: 6, OutputBytes: 2},
+			minDuration: 2000,
+		},
+		{
+			name:     "unknown operation",
+			op:       "missing",
+			input:    "abc",
+			expected: OpStats{InputBytes: 3, Error: CodeUnknownOperation},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MeasureOp(ops, tt.op, tt.input)
+			if result.DurationMicros < tt.minDuration || result.DurationMicros > time.Minute.Microseconds() {
+				t.Errorf("MeasureOp() failed\nInput:    %q\nExpected: duration_us between %d and one minute\nGot:      %d", tt.input, tt.minDuration, result.DurationMicros)
+			}
+
+			result.DurationMicros = 0
+			if result != tt.expected {
+				t.Errorf("MeasureOp() failed\nInput:    %q\nExpected: %+v\nGot:      %+v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOpStatsJSON(t *testing.T) {
+	data, err := json.Marshal(MeasureOp(map[string]func(string) string{"id": func(s string) string { return s }}, "id", "abc"))
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	for _, key := range []string{"result", "input_bytes", "output_bytes", "duration_us"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("json.Marshal() failed\nExpected field: %s\nGot:            %s", key, data)
+		}
+	}
+	if _, ok := fields["error"]; ok {
+		t.Errorf("json.Marshal() failed\nExpected no error field\nGot: %s", data)
+	}
+}
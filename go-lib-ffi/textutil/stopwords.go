@@ -0,0 +1,126 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("remove_stopwords")
+}
+
+// stopwords holds a small list of very frequent function words per
+// ISO 639-1 language code. The lists are short on purpose: they only need
+// to be distinctive enough to tell the languages apart, and they cover the
+// words that add the most noise to a search index.
+var stopwords = map[string]map[string]bool{
+	"en": wordSet("the", "and", "of", "to", "is", "in", "that", "it", "for", "was",
+		"with", "as", "on", "are", "this", "be", "by", "at", "from", "have",
+		"not", "or", "which", "you", "but", "they", "his", "her", "we", "were"),
+	"fr": wordSet("le", "la", "les", "des", "est", "et", "un", "une", "du", "dans",
+		"que", "qui", "pour", "pas", "sur", "au", "avec", "ce", "il", "elle",
+		"sont", "nous", "vous", "mais", "ou", "par", "plus", "cette", "aux", "été"),
+	"de": wordSet("der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den",
+		"von", "mit", "sich", "des", "auf", "für", "im", "dem", "auch", "es",
+		"wird", "werden", "sind", "oder", "aber", "wie", "bei", "nach", "noch", "wir"),
+	"es": wordSet("el", "la", "los", "las", "de", "que", "y", "en", "un", "una",
+		"es", "por", "con", "para", "del", "se", "no", "al", "lo", "como",
+		"más", "pero", "sus", "su", "este", "esta", "son", "fue", "ha", "muy"),
+	"it": wordSet("il", "di", "che", "e", "la", "le", "un", "una", "per", "non",
+		"sono", "del", "della", "con", "gli", "si", "è", "da", "questo", "come",
+		"anche", "nel", "alla", "ma", "più", "dei", "delle", "ha", "essere", "lo"),
+	"pt": wordSet("o", "a", "os", "as", "de", "que", "e", "do", "da", "em",
+		"um", "uma", "para", "com", "não", "por", "mais", "dos", "das", "se",
+		"na", "no", "ao", "como", "mas", "foi", "ele", "ela", "são", "também"),
+	"nl": wordSet("de", "het", "een", "en", "van", "is", "dat", "niet", "op", "te",
+		"in", "zijn", "met", "voor", "er", "aan", "ook", "als", "bij", "door",
+		"maar", "om", "wordt", "dit", "naar", "nog", "worden", "deze", "ze", "hij"),
+}
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// RemoveStopwords removes the bundled stopwords of lang, an ISO 639-1 code
+// optionally followed by a region such as "en-US", from text. Matching ignores
+// case. Punctuation and line breaks are kept, and the spaces that followed a
+// removed word, or preceded it when it ends a line or sentence, are dropped
+// with it so no double spaces are left behind.
+// Text in an unsupported language is returned unchanged.
+func RemoveStopwords(text, lang string) string {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(lang)), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	set, ok := stopwords[primary]
+	if !ok {
+		return text
+	}
+
+	out := make([]byte, 0, len(text))
+
+	// dropSpace is set after a removed word, skipped once spaces after it were dropped
+	dropSpace, skipped := false, false
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		if !isWordRune(r) {
+			// A removed word takes the spaces after it along, up to a line break
+			if dropSpace && (r == ' ' || r == '\t') {
+				skipped = true
+				i += size
+				continue
+			}
+			if dropSpace && !skipped {
+				// Punctuation or a line break right after the removed word:
+				// drop the space before the word instead
+				out = trimSpaceEnd(out)
+			}
+			dropSpace = false
+			out = append(out, text[i:i+size]...)
+			i += size
+			continue
+		}
+
+		end := i + size
+		for end < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[end:])
+			if !isWordRune(next) {
+				break
+			}
+			end += nextSize
+		}
+
+		word := text[i:end]
+		if set[strings.ToLower(word)] {
+			dropSpace, skipped = true, false
+		} else {
+			dropSpace = false
+			out = append(out, word...)
+		}
+		i = end
+	}
+
+	if dropSpace {
+		out = trimSpaceEnd(out)
+	}
+	return string(out)
+}
+
+// isWordRune reports whether r belongs to a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+}
+
+// trimSpaceEnd removes trailing spaces and tabs from b
+func trimSpaceEnd(b []byte) []byte {
+	for len(b) > 0 && (b[len(b)-1] == ' ' || b[len(b)-1] == '\t') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package textutil
+
+import "testing"
+
+func TestRemoveStopwords(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		lang     string
+		expected string
+	}{
+		{
+			name:     "english stopwords",
+			text:     "The history of the Go programming language",
+			lang:     "en",
+			expected: "history Go programming language",
+		},
+		{
+			name:     "punctuation kept",
+			text:     "Cats, dogs and birds are in the garden.",
+			lang:     "en",
+			expected: "Cats, dogs birds garden.",
+		},
+		{
+			name:     "stopword before punctuation",
+			text:     "What is it for? Nothing of the sort.",
+			lang:     "en",
+			expected: "What? Nothing sort.",
+		},
+		{
+			name:     "line breaks kept",
+			text:     "Read the\nmanual and\n\nthe FAQ",
+			lang:     "en",
+			expected: "Read\nmanual\n\nFAQ",
+		},
+		{
+			name:     "quotes and numbers kept",
+			text:     `Chapter 3 of the "Guide" by the team`,
+			lang:     "en",
+			expected: `Chapter 3 "Guide" team`,
+		},
+		{
+			name:     "words containing stopwords kept",
+			text:     "Then theory on online",
+			lang:     "en",
+			expected: "Then theory online",
+		},
+		{
+			name:     "region subtag",
+			text:     "Die Katze und der Hund",
+			lang:     "de-DE",
+			expected: "Katze Hund",
+		},
+		{
+			name:     "only stopwords",
+			text:     "of the and",
+			lang:     "EN",
+			expected: "",
+		},
+		{
+			name:     "unsupported language",
+			text:     "The history of the Go programming language",
+			lang:     "xx",
+			expected: "The history of the Go programming language",
+		},
+		{
+			name:     "empty language",
+			text:     "The cat",
+			lang:     "",
+			expected: "The cat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RemoveStopwords(tt.text, tt.lang)
+			if result != tt.expected {
+				t.Errorf("RemoveStopwords() failed\nInput:    %q (%s)\nExpected: %q\nGot:      %q", tt.text, tt.lang, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package textutil
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ellipsis marks text shortened by TruncateWords
+const Ellipsis = "…"
+
+// TruncateReport describes how much of an input was cut by a truncation
+type TruncateReport struct {
+	Truncated     bool `json:"truncated"`
+	OriginalBytes int  `json:"original_bytes"`
+	CutBytes      int  `json:"cut_bytes"`
+}
+
+// TruncateBytes shortens s so that the result, including marker, fits in maxBytes.
+// The cut always lands on a rune boundary so multi-byte characters are never split.
+// If the marker itself does not fit, the output is cut without it.
+// A maxBytes of 0 or less disables truncation.
+func TruncateBytes(s string, maxBytes int, marker string) (string, TruncateReport) {
+	report := TruncateReport{OriginalBytes: len(s)}
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s, report
+	}
+
+	budget := maxBytes - len(marker)
+	if budget < 0 {
+		budget = maxBytes
+		marker = ""
+	}
+
+	cut := RuneBoundary(s, budget)
+	report.Truncated = true
+	report.CutBytes = len(s) - cut
+
+	return s[:cut] + marker, report
+}
+
+// RuneBoundary returns the largest index <= n at which s can be cut
+// without splitting a UTF-8 encoded rune
+func RuneBoundary(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+	if n <= 0 {
+		return 0
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return n
+}
+
+// TruncateWords shortens s to at most maxChars runes, ellipsis included.
+// The cut is moved back to the last whitespace so words are kept whole;
+// a single word longer than the limit is cut mid-word on a rune boundary.
+// A maxChars of 0 or less disables truncation.
+func TruncateWords(s string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(s) <= maxChars {
+		return s
+	}
+
+	budget := maxChars - utf8.RuneCountInString(Ellipsis)
+	if budget <= 0 {
+		return Ellipsis
+	}
+
+	// Byte offset just past the first budget runes
+	cut := 0
+	for i := 0; i < budget; i++ {
+		_, size := utf8.DecodeRuneInString(s[cut:])
+		cut += size
+	}
+
+	// Back up to a word boundary unless the cut already falls on one
+	if next, _ := utf8.DecodeRuneInString(s[cut:]); !unicode.IsSpace(next) {
+		if space := strings.LastIndexFunc(s[:cut], unicode.IsSpace); space > 0 {
+			cut = space
+		}
+	}
+
+	return strings.TrimRightFunc(s[:cut], unicode.IsSpace) + Ellipsis
+}
+
+// TruncateSentences shortens s to at most maxChars runes like TruncateWords,
+// but cuts after the last complete sentence that fits so no ellipsis is
+// needed. A sentence ends with ".", "!" or "?" followed by whitespace or
+// the end of s, or with the CJK "。", "！" or "？"; closing quotes and
+// brackets right after the terminator stay with the sentence. When no
+// sentence fits, s is cut like TruncateWords.
+// A maxChars of 0 or less disables truncation.
+func TruncateSentences(s string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(s) <= maxChars {
+		return s
+	}
+
+	// Byte offset just past the first maxChars runes
+	limit := 0
+	for i := 0; i < maxChars; i++ {
+		_, size := utf8.DecodeRuneInString(s[limit:])
+		limit += size
+	}
+
+	end := 0
+	for i, r := range s[:limit] {
+		if !strings.ContainsRune(".!?。！？", r) {
+			continue
+		}
+		next := i + utf8.RuneLen(r)
+		for next < limit {
+			closer, size := utf8.DecodeRuneInString(s[next:])
+			if !strings.ContainsRune(sentenceClosers, closer) {
+				break
+			}
+			next += size
+		}
+		if next > limit {
+			continue
+		}
+
+		following, _ := utf8.DecodeRuneInString(s[next:])
+		if r >= utf8.RuneSelf || next == len(s) || unicode.IsSpace(following) {
+			end = next
+		}
+	}
+
+	if end == 0 {
+		return TruncateWords(s, maxChars)
+	}
+	return strings.TrimSpace(s[:end])
+}
+
+// sentenceClosers lists the quotes and brackets that may follow a sentence
+// terminator, e.g. the quote in `He said "Stop."`
+const sentenceClosers = ")]}\"'”’」』）"
+
+// MoreItems returns the line that replaces items cut from a list,
+// e.g. "… (+3 more)"
+func MoreItems(n int) string {
+	return Ellipsis + " (+" + strconv.Itoa(n) + " more)"
+}
@@ -0,0 +1,277 @@
+package textutil
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateBytes(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		maxBytes      int
+		marker        string
+		expected      string
+		expectedCut   int
+		expectedTrunc bool
+	}{
+		{
+			name:     "no limit",
+			input:    "Hello World",
+			maxBytes: 0,
+			marker:   "...",
+			expected: "Hello World",
+		},
+		{
+			name:     "exactly at limit",
+			input:    "Hello",
+			maxBytes: 5,
+			marker:   "...",
+			expected: "Hello",
+		},
+		{
+			name:          "one byte over limit",
+			input:         "Hello!",
+			maxBytes:      5,
+			marker:        "..",
+			expected:      "Hel..",
+			expectedCut:   3,
+			expectedTrunc: true,
+		},
+		{
+			name:          "marker larger than limit",
+			input:         "Hello World",
+			maxBytes:      3,
+			marker:        "[truncated]",
+			expected:      "Hel",
+			expectedCut:   8,
+			expectedTrunc: true,
+		},
+		{
+			name:          "multibyte rune at boundary",
+			input:         "héllo",
+			maxBytes:      2,
+			marker:        "",
+			expected:      "h",
+			expectedCut:   5,
+			expectedTrunc: true,
+		},
+		{
+			name:          "emoji is not split",
+			input:         "ab👋cd",
+			maxBytes:      5,
+			marker:        "",
+			expected:      "ab",
+			expectedCut:   6,
+			expectedTrunc: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, report := TruncateBytes(tt.input, tt.maxBytes, tt.marker)
+			if result != tt.expected {
+				t.Errorf("TruncateBytes() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("TruncateBytes() produced invalid UTF-8: %q", result)
+			}
+			if report.Truncated != tt.expectedTrunc || report.CutBytes != tt.expectedCut {
+				t.Errorf("TruncateBytes() report mismatch: %+v", report)
+			}
+			if report.OriginalBytes != len(tt.input) {
+				t.Errorf("TruncateBytes() original bytes = %d, want %d", report.OriginalBytes, len(tt.input))
+			}
+			if tt.maxBytes > 0 && len(result) > tt.maxBytes {
+				t.Errorf("TruncateBytes() output exceeds limit: %d > %d", len(result), tt.maxBytes)
+			}
+		})
+	}
+}
+
+func TestRuneBoundary(t *testing.T) {
+	s := "a世b"
+	tests := []struct {
+		n        int
+		expected int
+	}{
+		{n: -1, expected: 0},
+		{n: 0, expected: 0},
+		{n: 1, expected: 1},
+		{n: 2, expected: 1},
+		{n: 3, expected: 1},
+		{n: 4, expected: 4},
+		{n: 10, expected: len(s)},
+	}
+
+	for _, tt := range tests {
+		if got := RuneBoundary(s, tt.n); got != tt.expected {
+			t.Errorf("RuneBoundary(%q, %d) = %d, want %d", s, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxChars int
+		expected string
+	}{
+		{
+			name:     "no limit",
+			input:    "The quick brown fox",
+			maxChars: 0,
+			expected: "The quick brown fox",
+		},
+		{
+			name:     "below the limit",
+			input:    "The quick brown fox",
+			maxChars: 30,
+			expected: "The quick brown fox",
+		},
+		{
+			name:     "exactly at the limit",
+			input:    "The quick brown fox",
+			maxChars: 19,
+			expected: "The quick brown fox",
+		},
+		{
+			name:     "cut on a word boundary",
+			input:    "The quick brown fox jumps",
+			maxChars: 14,
+			expected: "The quick…",
+		},
+		{
+			name:     "cut right before a space",
+			input:    "The quick brown fox",
+			maxChars: 10,
+			expected: "The quick…",
+		},
+		{
+			name:     "single long word cut mid-word",
+			input:    "Supercalifragilistic",
+			maxChars: 6,
+			expected: "Super…",
+		},
+		{
+			name:     "multibyte runes are not split",
+			input:    "日本語のテキストです",
+			maxChars: 5,
+			expected: "日本語の…",
+		},
+		{
+			name:     "accented words",
+			input:    "café crème brûlée",
+			maxChars: 12,
+			expected: "café crème…",
+		},
+		{
+			name:     "limit smaller than the ellipsis",
+			input:    "Hello",
+			maxChars: 1,
+			expected: "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateWords(tt.input, tt.maxChars)
+			if result != tt.expected {
+				t.Errorf("TruncateWords() failed\nInput:    %q\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+			if tt.maxChars > 0 && utf8.RuneCountInString(result) > tt.maxChars {
+				t.Errorf("TruncateWords() failed\nInput:    %q\nExpected: at most %d runes\nGot:      %d", tt.input, tt.maxChars, utf8.RuneCountInString(result))
+			}
+		})
+	}
+}
+
+func TestTruncateSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxChars int
+		expected string
+	}{
+		{
+			name:     "no limit",
+			input:    "One. Two. Three.",
+			maxChars: 0,
+			expected: "One. Two. Three.",
+		},
+		{
+			name:     "fits",
+			input:    "One. Two. Three.",
+			maxChars: 16,
+			expected: "One. Two. Three.",
+		},
+		{
+			name:     "last whole sentence kept",
+			input:    "Go is fast. It compiles quickly! Does it scale? Very well indeed.",
+			maxChars: 50,
+			expected: "Go is fast. It compiles quickly! Does it scale?",
+		},
+		{
+			name:     "terminator at the limit",
+			input:    "Go is fast. It compiles quickly.",
+			maxChars: 11,
+			expected: "Go is fast.",
+		},
+		{
+			name:     "decimal point is not a sentence end",
+			input:    "Pi is about 3.14 and e is about 2.72 in value",
+			maxChars: 20,
+			expected: "Pi is about 3.14…",
+		},
+		{
+			name:     "closing quote kept with sentence",
+			input:    `He said "Stop." Then he left the room quietly.`,
+			maxChars: 30,
+			expected: `He said "Stop."`,
+		},
+		{
+			name:     "cjk terminators",
+			input:    "今日は晴れです。明日は雨でしょう！本当ですか？はい、そうです。",
+			maxChars: 20,
+			expected: "今日は晴れです。明日は雨でしょう！",
+		},
+		{
+			name:     "cjk terminator without following space",
+			input:    "你好。世界很大",
+			maxChars: 5,
+			expected: "你好。",
+		},
+		{
+			name:     "no sentence fits falls back to words",
+			input:    "A very long opening sentence without an end in sight",
+			maxChars: 20,
+			expected: "A very long opening…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateSentences(tt.input, tt.maxChars)
+			if result != tt.expected {
+				t.Errorf("TruncateSentences() failed\nInput:    %q (max %d)\nExpected: %q\nGot:      %q", tt.input, tt.maxChars, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMoreItems(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{n: 1, expected: "… (+1 more)"},
+		{n: 495, expected: "… (+495 more)"},
+	}
+
+	for _, tt := range tests {
+		if result := MoreItems(tt.n); result != tt.expected {
+			t.Errorf("MoreItems() failed\nInput:    %d\nExpected: %q\nGot:      %q", tt.n, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package textutil
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+
+	"go-lib-ffi/buildinfo"
+)
+
+func init() {
+	buildinfo.Register("utf16_input")
+}
+
+// DecodeUTF16LE decodes UTF-16LE data, such as a .NET or Windows string,
+// to UTF-8. A leading byte order mark is skipped, surrogate pairs are
+// combined, and unpaired surrogates become U+FFFD. A trailing odd byte
+// cannot form a code unit and is dropped.
+func DecodeUTF16LE(data []byte) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	if len(units) > 0 && units[0] == 0xFEFF {
+		units = units[1:]
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,56 @@
+package textutil
+
+import "testing"
+
+func TestDecodeUTF16LE(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{
+			name:     "empty",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "ascii",
+			input:    []byte{'<', 0, 'p', 0, '>', 0},
+			expected: "<p>",
+		},
+		{
+			name:     "non-ascii characters",
+			input:    []byte{'C', 0, 'a', 0, 'f', 0, 0xE9, 0x00, ' ', 0, 0xE5, 0x65, 0x2C, 0x67},
+			expected: "Café 日本",
+		},
+		{
+			name:     "byte order mark skipped",
+			input:    []byte{0xFF, 0xFE, 'h', 0, 'i', 0},
+			expected: "hi",
+		},
+		{
+			name:     "surrogate pair",
+			input:    []byte{'o', 0, 'k', 0, ' ', 0, 0x3D, 0xD8, 0x00, 0xDE},
+			expected: "ok 😀",
+		},
+		{
+			name:     "unpaired surrogate replaced",
+			input:    []byte{'a', 0, 0x3D, 0xD8, 'b', 0},
+			expected: "a�b",
+		},
+		{
+			name:     "odd trailing byte dropped",
+			input:    []byte{'a', 0, 'b'},
+			expected: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DecodeUTF16LE(tt.input)
+			if result != tt.expected {
+				t.Errorf("DecodeUTF16LE() failed\nInput:    % x\nExpected: %q\nGot:      %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}